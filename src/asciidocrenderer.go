@@ -0,0 +1,303 @@
+package main
+
+import (
+	"bufio"
+	"strings"
+)
+
+// asciidocRenderer writes AsciiDoc pages.
+type asciidocRenderer struct {
+	// page being rendered, set on a copy of the renderer by RenderPage
+	page *Page
+}
+
+func (a *asciidocRenderer) Extension() string {
+	return ".adoc"
+}
+
+func (a *asciidocRenderer) RenderPage(writer *bufio.Writer, page *Page) error {
+	r := *a
+	r.page = page
+	renderSections(&r, writer, page)
+	return nil
+}
+
+func (a *asciidocRenderer) RenderIndex(writer *bufio.Writer, index *IndexPage) error {
+	writer.WriteString("= " + index.Title + "\n")
+
+	for _, list := range []struct {
+		title   string
+		entries []IndexEntry
+	}{{"Classes", index.Classes}, {"Structs", index.Structs}, {"Enums", index.Enums}} {
+		if len(list.entries) == 0 {
+			continue
+		}
+		writer.WriteString("\n== " + list.title + "\n\n")
+		writer.WriteString("[cols=\"1,1,3\"]\n|===\n|Name |File |Summary\n\n")
+		for _, entry := range list.entries {
+			writer.WriteString("|<<" + entry.Href + ",`" + entry.Name + "`>> |`" + entry.File + "` |" + asciidocCell(entry.Summary) + "\n")
+		}
+		writer.WriteString("|===\n")
+	}
+
+	if len(index.Folders) > 0 {
+		writer.WriteString("\n== Files\n")
+	}
+	for _, folder := range index.Folders {
+		writer.WriteString("\n=== `" + folder.Name + "`\n\n")
+		writer.WriteString("[cols=\"1,3\"]\n|===\n|File |Summary\n\n")
+		for _, file := range folder.Files {
+			writer.WriteString("|xref:" + file.Href + "[`" + file.Name + "`] |" + asciidocCell(file.Summary) + "\n")
+		}
+		writer.WriteString("|===\n")
+	}
+	return nil
+}
+
+// asciidocCell escapes the cell separators of text so it stays in one table cell.
+func asciidocCell(text string) string {
+	return strings.ReplaceAll(text, "|", "{vbar}")
+}
+
+// typeLink returns a cross reference to the section documenting the type name, or name as code when it is unknown.
+func (a *asciidocRenderer) typeLink(name string) string {
+	if symbol, ok := a.page.Project.Symbols.Lookup(name); ok {
+		return "<<" + strings.TrimPrefix(a.page.Project.Symbols.Href(a.page.Path, symbol), "#") + ",`" + name + "`>>"
+	}
+	return "`" + name + "`"
+}
+
+// typeMentions writes cross references to the known types used by declarations, which listings cannot link themselves.
+func (a *asciidocRenderer) typeMentions(writer *bufio.Writer, declarations []string, owner string) {
+	symbols := a.page.typeMentions(declarations, owner)
+	if len(symbols) == 0 {
+		return
+	}
+	var links []string
+	for _, symbol := range symbols {
+		links = append(links, a.typeLink(symbol.Name))
+	}
+	writer.WriteString("\n*Types:* " + strings.Join(links, ", ") + "\n")
+}
+
+func (a *asciidocRenderer) BeginPage(writer *bufio.Writer, page *Page) {
+	writer.WriteString("= " + page.File.Name + "\n")
+	writer.WriteString(":description: Reference page for " + page.File.Name + "\n")
+	writer.WriteString("\n== File Info\n")
+}
+
+func (a *asciidocRenderer) EndPage(writer *bufio.Writer, page *Page) {
+}
+
+func (a *asciidocRenderer) FileSummary(writer *bufio.Writer, f *FileInfo, enums, structs, classes []DataInfo) {
+	writer.WriteString("\n*FileName:* `" + f.Name + "`\n\n")
+	a.typeList(writer, "Enum List", enums, false)
+	a.typeList(writer, "Struct List", structs, true)
+	a.typeList(writer, "Class List", classes, true)
+}
+
+func (a *asciidocRenderer) typeList(writer *bufio.Writer, label string, types []DataInfo, documentedOnly bool) {
+	var links []string
+	for _, d := range types {
+		if !documentedOnly || d.HasDocumentation() {
+			links = append(links, "<<"+a.page.typeAnchor(&d)+",`"+a.page.typeName(&d)+"`>>")
+		}
+	}
+	if len(links) > 0 {
+		writer.WriteString("* *" + label + ":* " + strings.Join(links, " {vbar} ") + "\n")
+	}
+}
+
+func (a *asciidocRenderer) TypeHeader(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("\n[[" + a.page.typeAnchor(d) + "]]\n== `" + a.page.typeName(d) + "`\n")
+}
+
+func (a *asciidocRenderer) EnumHeader(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("\n[[" + a.page.typeAnchor(d) + "]]\n=== `" + a.page.typeName(d) + "`\n")
+}
+
+func (a *asciidocRenderer) Parents(writer *bufio.Writer, d *DataInfo) {
+	if len(d.Parents) == 0 {
+		return
+	}
+	var parents []string
+	for _, parent := range a.page.Project.Parents(d) {
+		parents = append(parents, a.typeLink(parent))
+	}
+	writer.WriteString("\n*Parent Classes:* " + strings.Join(parents, ", ") + "\n")
+}
+
+func (a *asciidocRenderer) Derived(writer *bufio.Writer, derived derivedList) {
+	if len(derived.Names) == 0 {
+		return
+	}
+	var links []string
+	for _, name := range derived.Names {
+		links = append(links, a.typeLink(name))
+	}
+	writer.WriteString("\n*" + derived.Title + ":* " + strings.Join(links, ", ") + "\n")
+}
+
+func (a *asciidocRenderer) Badges(writer *bufio.Writer, badges []string) {
+	if len(badges) > 0 {
+		writer.WriteString("\n*Specifiers:* " + strings.Join(badges, ", ") + "\n")
+	}
+}
+
+func (a *asciidocRenderer) Description(writer *bufio.Writer, d *DataInfo) {
+	doc := d.Doc()
+	if len(doc.Text) > 0 {
+		writer.WriteString("\n" + a.textLines(doc.Text) + "\n")
+	}
+	a.docTags(writer, &doc)
+}
+
+// textLines joins the lines of a comment with hard line breaks.
+func (a *asciidocRenderer) textLines(text []string) string {
+	return strings.Join(text, " +\n")
+}
+
+// docTags writes the tags of a doc comment: the parameters table, the returned value and the see also links, then
+// the deprecation, notes and warnings as admonitions.
+func (a *asciidocRenderer) docTags(writer *bufio.Writer, doc *DocComment) {
+	if len(doc.Params) > 0 {
+		writer.WriteString("\n*Parameters:*\n")
+		writer.WriteString("\n[cols=\"1,2,1,3\",options=\"header\"]\n|===\n")
+		writer.WriteString("|Name |Type |Default |Description\n")
+		for _, param := range doc.Params {
+			writer.WriteString("\n|" + a.codeCell(param.Name) + " |" + a.codeCell(param.Type) + " |" + a.codeCell(param.Default) + " |" + a.escapeCell(param.Description) + "\n")
+		}
+		writer.WriteString("|===\n")
+	}
+	if doc.Returns != "" {
+		writer.WriteString("\n*Returns:* " + doc.Returns + "\n")
+	}
+	if len(doc.See) > 0 {
+		var links []string
+		for _, ref := range doc.See {
+			link := "`" + ref + "`"
+			if symbol, ok := a.page.Project.Symbols.LookupReference(ref); ok {
+				link = "<<" + strings.TrimPrefix(a.page.Project.Symbols.Href(a.page.Path, symbol), "#") + "," + link + ">>"
+			}
+			links = append(links, link)
+		}
+		writer.WriteString("\n*See also:* " + strings.Join(links, ", ") + "\n")
+	}
+	if doc.Deprecated {
+		writer.WriteString("\n[CAUTION]\n.Deprecated\n====\n" + doc.DeprecationNotice() + "\n====\n")
+	}
+	for _, note := range doc.Notes {
+		writer.WriteString("\nNOTE: " + note + "\n")
+	}
+	for _, warning := range doc.Warnings {
+		writer.WriteString("\nWARNING: " + warning + "\n")
+	}
+}
+
+func (a *asciidocRenderer) Example(writer *bufio.Writer, example string) {
+	if example != "" {
+		writer.WriteString("\n*Example:*\n\n[source,cpp]\n----\n" + example + "----\n")
+	}
+}
+
+// Diagram writes a block for the mermaid extension of asciidoctor-diagram.
+func (a *asciidocRenderer) Diagram(writer *bufio.Writer, mermaid string) {
+	if mermaid != "" {
+		writer.WriteString("\n[mermaid]\n....\n" + mermaid + "....\n")
+	}
+}
+
+func (a *asciidocRenderer) Properties(writer *bufio.Writer, d *DataInfo) {
+	if !d.HasDocumentedProperties() {
+		return
+	}
+
+	writer.WriteString("\n=== Properties\n")
+	a.propertyGroup(writer, propertyGroups(d))
+}
+
+// heading returns the section title of a level, or title as a bold label past the deepest AsciiDoc section.
+func (a *asciidocRenderer) heading(level int, title string) string {
+	if level > maxHeadingLevel {
+		return "*" + title + "*"
+	}
+	return strings.Repeat("=", level) + " " + title
+}
+
+// propertyGroup writes the properties of a Category in one listing, then its sub categories as nested sections.
+func (a *asciidocRenderer) propertyGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		writer.WriteString("\n" + a.heading(headingLevel(3, group.Depth), group.Name) + "\n")
+	}
+
+	if len(group.Properties) > 0 {
+		writer.WriteString("\n[source,cpp]\n----\n")
+		for _, prop := range group.Properties {
+			for _, comm := range prop.Documentation() {
+				writer.WriteString("// " + cleanComment(comm) + "\n")
+			}
+			if prop.Macro != "" {
+				writer.WriteString(prop.Macro + "\n")
+			}
+			writer.WriteString(prop.Declaration + "\n\n")
+		}
+		writer.WriteString("----\n")
+		a.typeMentions(writer, group.Declarations(), group.Owner)
+	}
+
+	for _, sub := range group.Groups {
+		a.propertyGroup(writer, sub)
+	}
+}
+
+func (a *asciidocRenderer) Functions(writer *bufio.Writer, d *DataInfo) {
+	if !d.HasDocumentedFunctions() {
+		return
+	}
+
+	writer.WriteString("\n=== Functions\n")
+	a.functionGroup(writer, functionGroups(d))
+}
+
+// functionGroup writes the functions of a Category, then its sub categories as nested sections.
+func (a *asciidocRenderer) functionGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		writer.WriteString("\n" + a.heading(headingLevel(3, group.Depth), group.Name) + "\n")
+	}
+
+	for _, function := range group.Functions {
+		writer.WriteString("\n" + a.heading(headingLevel(4, group.Depth), "`"+function.Name+"`") + "\n")
+		a.Badges(writer, function.Specifiers.Badges())
+		doc := function.Doc()
+		writer.WriteString("\n____\n" + a.textLines(doc.Text) + "\n____\n\n")
+		writer.WriteString("[source,cpp]\n----\n" + function.Declaration + "\n----\n")
+		a.typeMentions(writer, []string{function.Declaration}, group.Owner)
+		a.docTags(writer, &doc)
+		a.Example(writer, function.Example)
+	}
+
+	for _, sub := range group.Groups {
+		a.functionGroup(writer, sub)
+	}
+}
+
+func (a *asciidocRenderer) EnumValues(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("\n[cols=\"1,3\",options=\"header\"]\n|===\n")
+	writer.WriteString("|Value |Description\n")
+	for _, prop := range d.Properties {
+		writer.WriteString("\n|`" + a.escapeCell(enumValueName(&prop)) + "` |" + a.escapeCell(enumValueDescription(&prop)) + "\n")
+	}
+	writer.WriteString("|===\n")
+}
+
+func (a *asciidocRenderer) escapeCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// codeCell returns text as code in a table cell, or an empty cell.
+func (a *asciidocRenderer) codeCell(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "`+" + a.escapeCell(text) + "+`"
+}
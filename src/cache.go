@@ -0,0 +1,148 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+)
+
+// Name of the parse cache written into the destination folder by generate.
+const cacheFileName = ".go-cpp-mk-cache.json"
+
+// Version of the cache layout. Bump it whenever FileInfo changes shape or headers parse differently.
+const cacheVersion = 4
+
+// parseCache keeps the parsed model of every header by its path relative to the source folder, so headers whose
+// content did not change since the previous run are not parsed again.
+type parseCache struct {
+	Version int `json:"version"`
+	// Key identifies the build and config the entries were parsed with, see cacheKey
+	Key   string                `json:"key"`
+	Files map[string]cacheEntry `json:"files"`
+}
+
+type cacheEntry struct {
+	// Hash is the SHA-256 of the header content
+	Hash  string     `json:"hash"`
+	Types []DataInfo `json:"types"`
+}
+
+func newParseCache(key string) *parseCache {
+	return &parseCache{Version: cacheVersion, Key: key, Files: map[string]cacheEntry{}}
+}
+
+// cacheKey returns the hash of the build and of cfg: another build or another config parses everything again.
+// The build is identified by its module checksum or VCS revision, see buildRevision, and by cacheVersion.
+func cacheKey(cfg *Config) string {
+	config, err := json.Marshal(cfg)
+	if err != nil {
+		return ""
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%d\n%s\n", cacheVersion, buildRevision())
+	hash.Write(config)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// buildRevision returns the checksum of the main module when installed with go install, or else the VCS revision it
+// was built from, suffixed with "+dirty" for uncommitted changes. It is "" when the build has neither, the cache then
+// only follows cacheVersion.
+func buildRevision() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	if info.Main.Sum != "" {
+		return info.Main.Sum
+	}
+
+	revision, modified := "", false
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			revision = setting.Value
+		case "vcs.modified":
+			modified = setting.Value == "true"
+		}
+	}
+	if revision != "" && modified {
+		revision += "+dirty"
+	}
+	return revision
+}
+
+// loadCache reads the cache of destFolder. A missing or unreadable cache, or one written with another key,
+// is reported at the verbose level and replaced with an empty one.
+func loadCache(destFolder, key string) *parseCache {
+	cache := newParseCache(key)
+	if key == "" || destFolder == "" {
+		return cache
+	}
+
+	cachePath := filepath.Join(destFolder, cacheFileName)
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		logDebug("No parse cache read from %s: %v\n", cachePath, err)
+		return cache
+	}
+
+	var stored parseCache
+	if err := json.Unmarshal(content, &stored); err != nil {
+		logDebug("Ignoring parse cache %s: %v\n", cachePath, err)
+		return cache
+	}
+	if stored.Version != cacheVersion || stored.Key != key || stored.Files == nil {
+		logDebug("Ignoring parse cache %s, written by another build or config\n", cachePath)
+		return cache
+	}
+	return &stored
+}
+
+// parseFile parses the header of fileInfo, or copies its types from the cache when its content is unchanged.
+// It returns the hash of the content and whether the cache was used.
+func (c *parseCache) parseFile(fileInfo *FileInfo, cfg *Config) (string, bool, error) {
+	content, err := os.ReadFile(fileInfo.Path)
+	if err != nil {
+		return "", false, fmt.Errorf("opening file %s: %w", fileInfo.Path, err)
+	}
+
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])
+	if entry, ok := c.Files[fileInfo.RelPath]; ok && entry.Hash == hash {
+		fileInfo.Data = entry.Types
+		return hash, true, nil
+	}
+
+	if err := extractInfo(bytes.NewReader(content), fileInfo, cfg); err != nil {
+		return hash, false, fmt.Errorf("reading file %s: %w", fileInfo.Path, err)
+	}
+	return hash, false, nil
+}
+
+// saveCache writes the parsed headers of project into the cache of destFolder.
+func saveCache(destFolder string, project *Project) error {
+	if project.cacheKey == "" {
+		return nil
+	}
+
+	cache := newParseCache(project.cacheKey)
+	for i := range project.Files {
+		fileInfo := &project.Files[i]
+		cache.Files[fileInfo.RelPath] = project.parsed[fileInfo.RelPath]
+	}
+
+	content, err := json.Marshal(cache)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(destFolder, cacheFileName), content, 0644); err != nil {
+		return fmt.Errorf("writing parse cache: %w", err)
+	}
+	return nil
+}
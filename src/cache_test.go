@@ -0,0 +1,129 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// generateCache parses folder as the generate command does and writes its parse cache into a new destination folder.
+func generateCache(t *testing.T, folder string) (string, *Project) {
+	t.Helper()
+	dest := t.TempDir()
+	opts, err := parseOptions(findCommand("generate"), []string{"-q", folder, dest})
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, failures, err := loadProject(opts)
+	if err != nil || failures != 0 {
+		t.Fatalf("loadProject: %d failures, %v", failures, err)
+	}
+	if err := saveCache(dest, project); err != nil {
+		t.Fatal(err)
+	}
+	return dest, project
+}
+
+// parseFromCache parses the header of fileInfo with the cache of dest read with key, and reports whether the cache
+// was used.
+func parseFromCache(t *testing.T, dest, key string, fileInfo FileInfo, cfg *Config) (FileInfo, bool) {
+	t.Helper()
+	fileInfo.Data = nil
+	_, cached, err := loadCache(dest, key).parseFile(&fileInfo, cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return fileInfo, cached
+}
+
+func TestParseCache(t *testing.T) {
+	folder := writeHeaders(t, 2)
+	dest, project := generateCache(t, folder)
+	cfg := project.Config
+	key := project.cacheKey
+	if key == "" {
+		t.Fatal("no cache key")
+	}
+	if again := cacheKey(cfg); again != key {
+		t.Errorf("cacheKey changes between two calls: %s, then %s", key, again)
+	}
+
+	// Unchanged headers are read from the cache
+	for _, fileInfo := range project.Files {
+		got, cached := parseFromCache(t, dest, key, fileInfo, cfg)
+		if !cached {
+			t.Errorf("%s: unchanged header parsed again", fileInfo.Name)
+		} else if !reflect.DeepEqual(got.Data, project.parsed[fileInfo.RelPath].Types) {
+			t.Errorf("%s: cached types differ from the parsed ones", fileInfo.Name)
+		}
+	}
+
+	// Another config or build does not use the entries
+	other := *cfg
+	other.Output.Visibility = "public"
+	if cacheKey(&other) == key {
+		t.Error("cacheKey ignores the config")
+	}
+	if _, cached := parseFromCache(t, dest, cacheKey(&other), project.Files[0], cfg); cached {
+		t.Error("cache used with another key")
+	}
+
+	// Edited headers are parsed again
+	edited := project.Files[1]
+	if err := os.WriteFile(edited.Path, []byte("class FEdited { int32 X; };\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got, cached := parseFromCache(t, dest, key, edited, cfg)
+	if cached {
+		t.Errorf("%s: edited header read from the cache", edited.Name)
+	} else if len(got.Data) != 1 || got.Data[0].Name != "FEdited" {
+		t.Errorf("%s: edited header parsed as %+v", edited.Name, got.Data)
+	}
+	if _, cached := parseFromCache(t, dest, key, project.Files[0], cfg); !cached {
+		t.Errorf("%s: unchanged header parsed again after editing another one", project.Files[0].Name)
+	}
+}
+
+func TestParseCacheInvalid(t *testing.T) {
+	folder := writeHeaders(t, 1)
+	dest, project := generateCache(t, folder)
+	cachePath := filepath.Join(dest, cacheFileName)
+	content, err := os.ReadFile(cachePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var stored parseCache
+	if err := json.Unmarshal(content, &stored); err != nil {
+		t.Fatal(err)
+	}
+	stored.Version = cacheVersion - 1
+	oldVersion, err := json.Marshal(stored)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name    string
+		content []byte
+	}{
+		{"older version", oldVersion},
+		{"corrupt", []byte(`{"version": 4, "files": {`)},
+		{"not an object", []byte(`[1, 2]`)},
+		{"no files", []byte(`{"version": ` + strconv.Itoa(cacheVersion) + `, "key": "` + project.cacheKey + `"}`)},
+		{"empty", nil},
+	} {
+		if err := os.WriteFile(cachePath, test.content, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		got, cached := parseFromCache(t, dest, project.cacheKey, project.Files[0], project.Config)
+		if cached {
+			t.Errorf("%s cache used", test.name)
+		} else if !reflect.DeepEqual(got.Data, project.parsed[project.Files[0].RelPath].Types) {
+			t.Errorf("%s cache: header parsed differently", test.name)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"slices"
+	"strings"
+)
+
+// categorySeparator separates nested categories, e.g. "FlowPilot|Conditions".
+const categorySeparator = "|"
+
+// memberGroup holds the documented members of one UE Category, like a section of the editor details panel.
+// The root group has no name, it holds the members without a Category and the top level categories, or the
+// Public, Protected and Private groups when not every member is public.
+type memberGroup struct {
+	Name string
+	// Owner is the name of the type the members belong to
+	Owner string
+	// Depth is 0 for the root group, 1 for access levels or top level categories, 2 for their sub categories, ...
+	Depth      int
+	Properties []PropertyInfo
+	Functions  []FunctionInfo
+	Groups     []*memberGroup
+}
+
+// child returns the sub group called name, creating it after the existing ones.
+func (g *memberGroup) child(name string) *memberGroup {
+	for _, group := range g.Groups {
+		if group.Name == name {
+			return group
+		}
+	}
+	group := &memberGroup{Name: name, Owner: g.Owner, Depth: g.Depth + 1}
+	g.Groups = append(g.Groups, group)
+	return group
+}
+
+// find returns the group of category, creating the missing groups along its path.
+func (g *memberGroup) find(category string) *memberGroup {
+	group := g
+	for _, name := range strings.Split(category, categorySeparator) {
+		if name = strings.TrimSpace(name); name != "" {
+			group = group.child(name)
+		}
+	}
+	return group
+}
+
+// accessGroups returns the root group of a section and the group members of each access level go in. When every
+// member is public the root is used for all of them, otherwise the root gets a Public, Protected and Private group
+// for the levels present, in that order.
+func accessGroups(owner string, accesses []AccessType) (*memberGroup, func(AccessType) *memberGroup) {
+	root := &memberGroup{Owner: owner}
+
+	split := false
+	for _, access := range accesses {
+		if access != Public {
+			split = true
+		}
+	}
+	if !split {
+		return root, func(AccessType) *memberGroup { return root }
+	}
+
+	for _, access := range []AccessType{Public, Protected, Private} {
+		if slices.Contains(accesses, access) {
+			root.child(accessModifierString(access))
+		}
+	}
+	return root, func(access AccessType) *memberGroup { return root.child(accessModifierString(access)) }
+}
+
+// propertyGroups groups the documented properties of d by access level and Category, in declaration order.
+func propertyGroups(d *DataInfo) *memberGroup {
+	var documented []PropertyInfo
+	var accesses []AccessType
+	for _, prop := range d.Properties {
+		if len(prop.Documentation()) > 0 {
+			documented = append(documented, prop)
+			accesses = append(accesses, prop.Access)
+		}
+	}
+
+	root, accessGroup := accessGroups(d.Name, accesses)
+	for _, prop := range documented {
+		group := accessGroup(prop.Access).find(prop.Specifiers.Category())
+		group.Properties = append(group.Properties, prop)
+	}
+	return root
+}
+
+// functionGroups groups the documented functions of d by access level and Category, in declaration order.
+func functionGroups(d *DataInfo) *memberGroup {
+	var documented []FunctionInfo
+	var accesses []AccessType
+	for _, function := range d.Functions {
+		if len(function.Documentation()) > 0 {
+			documented = append(documented, function)
+			accesses = append(accesses, function.Access)
+		}
+	}
+
+	root, accessGroup := accessGroups(d.Name, accesses)
+	for _, function := range documented {
+		group := accessGroup(function.Access).find(function.Specifiers.Category())
+		group.Functions = append(group.Functions, function)
+	}
+	return root
+}
+
+// Declarations returns the declarations of the properties, then of the functions of the group.
+func (g *memberGroup) Declarations() []string {
+	var declarations []string
+	for _, prop := range g.Properties {
+		declarations = append(declarations, prop.Declaration)
+	}
+	for _, function := range g.Functions {
+		declarations = append(declarations, function.Declaration)
+	}
+	return declarations
+}
+
+// maxHeadingLevel is the deepest heading of markdown and HTML. Deeper groups and functions are written as bold labels,
+// so they stay below their parent group rather than becoming its sibling.
+const maxHeadingLevel = 6
+
+// headingLevel returns the level of a heading depth levels below base, above maxHeadingLevel for bold labels.
+func headingLevel(base, depth int) int {
+	return base + depth
+}
@@ -0,0 +1,645 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Exit codes returned by run.
+const (
+	exitOk      = 0
+	exitFailure = 1
+	exitUsage   = 2
+)
+
+// Verbosity levels set by -q and -v.
+const (
+	verbosityQuiet = iota
+	verbosityNormal
+	verbosityVerbose
+)
+
+var verbosity = verbosityNormal
+
+// pageFormats returns the names accepted by -format: every renderer, plus the json export.
+func pageFormats() []string {
+	return append(rendererNames(), "json")
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type options struct {
+	SourceFolder string
+	DestFolder   string
+	ConfigPath   string
+	Config       Config
+	Format       string
+	Templates    string
+	Visibility   string
+	Renderer     Renderer
+	// Jobs is the number of headers parsed or rendered at the same time
+	Jobs int
+	// Interval between two polls of the source folder by watch
+	Interval time.Duration
+	// Report is the format of the coverage report, ReportPath its file, or "" for stdout
+	Report     string
+	ReportPath string
+	// MinCoverage is the documented percentage below which coverage fails
+	MinCoverage float64
+	DryRun      bool
+	Force       bool
+	Combined    bool
+	Include     stringList
+	Exclude     stringList
+}
+
+type command struct {
+	Name        string
+	Args        string
+	Description string
+	// Number of positional arguments: MinArgs are required, up to MaxArgs are accepted.
+	MinArgs int
+	MaxArgs int
+	Run     func(opts *options) error
+	// Flags are the names of the flags only this command reads, beside the common ones
+	Flags []string
+}
+
+var commands = []command{
+	{"generate", "<source_folder> <destination_folder>", "parse the headers and write one page per header", 2, 2, runGenerate, []string{"force", "dry-run", "combined"}},
+	{"check", "<source_folder> [destination_folder]", "parse and render every header without writing, report problems", 1, 2, runCheck, []string{"force", "combined"}},
+	{"list", "<source_folder>", "list the types found in every header", 1, 1, runList, nil},
+	{"diff", "<source_folder> <destination_folder>", "show what generate would change in the destination folder", 2, 2, runDiff, []string{"force", "combined"}},
+	{"watch", "<source_folder> <destination_folder>", "generate, then regenerate the pages whenever a header changes", 2, 2, runWatch, []string{"interval", "force", "dry-run", "combined"}},
+	{"coverage", "<source_folder>", "report the share of documented types, UPROPERTYs and UFUNCTIONs", 1, 1, runCoverage, []string{"report", "o", "min-coverage"}},
+	{"init", "[source_folder]", "write a starter config file into the source folder", 0, 1, runInit, []string{"force", "dry-run"}},
+}
+
+// hasFlag reports whether the command reads the flag name, see command.Flags.
+func (c *command) hasFlag(name string) bool {
+	return containsString(c.Flags, name)
+}
+
+func findCommand(name string) *command {
+	for i := range commands {
+		if commands[i].Name == name {
+			return &commands[i]
+		}
+	}
+	return nil
+}
+
+func printUsage() {
+	fmt.Fprintln(os.Stderr, "Usage: go-cpp-mk <command> [flags] <arguments>")
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-9s %s\n", cmd.Name, cmd.Description)
+	}
+	fmt.Fprintln(os.Stderr, "")
+	fmt.Fprintln(os.Stderr, "Run 'go-cpp-mk <command> -h' for the flags of a command.")
+	fmt.Fprintln(os.Stderr, "'go-cpp-mk <source_folder> <destination_folder>' is a shortcut for generate.")
+}
+
+// run executes the command line and returns the process exit code.
+func run(args []string) int {
+	if len(args) == 0 {
+		printUsage()
+		return exitUsage
+	}
+
+	if args[0] == "help" || args[0] == "-h" || args[0] == "-help" || args[0] == "--help" {
+		printUsage()
+		return exitOk
+	}
+
+	cmd := findCommand(args[0])
+	if cmd != nil {
+		args = args[1:]
+	} else if len(args) == 2 && !strings.HasPrefix(args[0], "-") && !strings.HasPrefix(args[1], "-") {
+		// Keep the original '<source_folder> <destination_folder>' invocation working
+		cmd = findCommand("generate")
+	} else {
+		fmt.Fprintf(os.Stderr, "Error: unknown command %q\n\n", args[0])
+		printUsage()
+		return exitUsage
+	}
+
+	opts, err := parseOptions(cmd, args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOk
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitUsage
+	}
+
+	if err := cmd.Run(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return exitFailure
+	}
+	return exitOk
+}
+
+func parseOptions(cmd *command, args []string) (*options, error) {
+	opts := &options{}
+
+	flags := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	flags.StringVar(&opts.ConfigPath, "config", "", "path to a config file (default: discovered in the source folder)")
+	flags.StringVar(&opts.Format, "format", "", "output format: "+strings.Join(pageFormats(), ", ")+" (default: output.format of the config, or mdx)")
+	flags.StringVar(&opts.Templates, "templates", "", "folder of *.tmpl files replacing the built-in mdx templates (default: output.templates of the config)")
+	flags.StringVar(&opts.Visibility, "visibility", "", "members to document: public, protected or all (default: output.visibility of the config, or all)")
+	flags.IntVar(&opts.Jobs, "j", defaultJobs(), "number of headers parsed and rendered in parallel")
+	flags.Var(&opts.Include, "include", "glob of headers to include, replaces the configured list (repeatable)")
+	flags.Var(&opts.Exclude, "exclude", "glob of headers to exclude, added to the configured list (repeatable)")
+	quiet := flags.Bool("q", false, "only print errors")
+	verbose := flags.Bool("v", false, "print every step")
+
+	// Flags of a single command are only registered on the commands reading them
+	opts.Interval = 500 * time.Millisecond
+	opts.Report = "text"
+	if cmd.hasFlag("interval") {
+		flags.DurationVar(&opts.Interval, "interval", opts.Interval, "time between two polls of the source folder, changes are generated once stable for a whole interval")
+	}
+	if cmd.hasFlag("report") {
+		flags.StringVar(&opts.Report, "report", opts.Report, "report format: "+strings.Join(coverageFormats, ", "))
+	}
+	if cmd.hasFlag("o") {
+		flags.StringVar(&opts.ReportPath, "o", "", "write the report to this file instead of the standard output")
+	}
+	if cmd.hasFlag("min-coverage") {
+		flags.Float64Var(&opts.MinCoverage, "min-coverage", 0, "fail when less than this percentage of the public symbols is documented")
+	}
+	if cmd.hasFlag("dry-run") {
+		flags.BoolVar(&opts.DryRun, "dry-run", false, "report what would be written without touching any file")
+	}
+	if cmd.hasFlag("force") {
+		flags.BoolVar(&opts.Force, "force", false, "parse every header and rewrite every page, ignoring the parse cache (generate), overwrite an existing config (init)")
+	}
+	if cmd.hasFlag("combined") {
+		flags.BoolVar(&opts.Combined, "combined", false, "json: write one "+jsonIndexFileName+" for all headers instead of one file per header")
+	}
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: go-cpp-mk %s [flags] %s\n\n%s\n\nFlags:\n", cmd.Name, cmd.Args, cmd.Description)
+		flags.PrintDefaults()
+	}
+
+	// Flags may appear before, between or after the positional arguments
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			break
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+
+	if len(positional) < cmd.MinArgs || len(positional) > cmd.MaxArgs {
+		flags.Usage()
+		return nil, fmt.Errorf("%s expects %s", cmd.Name, cmd.Args)
+	}
+
+	if opts.Interval <= 0 {
+		return nil, fmt.Errorf("-interval must be positive, got %s", opts.Interval)
+	}
+	if opts.Jobs < 1 {
+		return nil, fmt.Errorf("-j must be at least 1, got %d", opts.Jobs)
+	}
+	if !containsString(coverageFormats, opts.Report) {
+		return nil, fmt.Errorf("unknown report %q, expected one of %s", opts.Report, strings.Join(coverageFormats, ", "))
+	}
+	if opts.MinCoverage < 0 || opts.MinCoverage > 100 {
+		return nil, fmt.Errorf("-min-coverage must be between 0 and 100, got %g", opts.MinCoverage)
+	}
+
+	switch {
+	case *quiet:
+		verbosity = verbosityQuiet
+	case *verbose:
+		verbosity = verbosityVerbose
+	default:
+		verbosity = verbosityNormal
+	}
+
+	opts.SourceFolder = "."
+	if len(positional) > 0 {
+		opts.SourceFolder = positional[0]
+	}
+	if len(positional) > 1 {
+		opts.DestFolder = positional[1]
+	}
+
+	// init writes a starter config, it never reads an existing one
+	cfg := DefaultConfig()
+	if cmd.Name != "init" {
+		var err error
+		cfg, err = loadConfig(opts.ConfigPath, opts.SourceFolder)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(opts.Include) > 0 {
+		cfg.Include = opts.Include
+	}
+	cfg.Exclude = append(cfg.Exclude, opts.Exclude...)
+
+	if opts.Format != "" {
+		cfg.Output.Format = opts.Format
+	}
+	if cfg.Output.Format == "" {
+		cfg.Output.Format = "mdx"
+	}
+	opts.Format = cfg.Output.Format
+
+	if opts.Visibility != "" {
+		cfg.Output.Visibility = opts.Visibility
+	}
+	if cfg.Output.Visibility == "" {
+		cfg.Output.Visibility = "all"
+	}
+	opts.Visibility = cfg.Output.Visibility
+	if _, err := cfg.MaxAccess(); err != nil {
+		return nil, err
+	}
+
+	if cmd.Name == "init" {
+		opts.Config = cfg
+		return opts, nil
+	}
+
+	if !containsString(pageFormats(), opts.Format) {
+		return nil, fmt.Errorf("unknown format %q, expected one of %s", opts.Format, strings.Join(pageFormats(), ", "))
+	}
+	if opts.Templates != "" {
+		cfg.Output.Templates = opts.Templates
+	}
+	opts.Templates = cfg.Output.Templates
+
+	if opts.Templates != "" && opts.Format != "mdx" && opts.Format != "json" {
+		return nil, fmt.Errorf("templates only apply to the mdx format, not %s", opts.Format)
+	}
+	if opts.Format != "json" {
+		var renderer Renderer
+		var err error
+		if opts.Templates != "" {
+			renderer, err = newTemplateRenderer(opts.Templates)
+		} else {
+			renderer, err = newRenderer(opts.Format)
+		}
+		if err != nil {
+			return nil, err
+		}
+		opts.Renderer = renderer
+		if cfg.Output.Extension == "" {
+			cfg.Output.Extension = renderer.Extension()
+		}
+	}
+	opts.Config = cfg
+
+	return opts, nil
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
+
+func logInfo(format string, args ...any) {
+	if verbosity >= verbosityNormal {
+		fmt.Printf(format, args...)
+	}
+}
+
+func logDebug(format string, args ...any) {
+	if verbosity >= verbosityVerbose {
+		fmt.Printf(format, args...)
+	}
+}
+
+// logWarn writes a warning to the standard error, so it does not mix with reports written to the standard output.
+func logWarn(format string, args ...any) {
+	if verbosity >= verbosityNormal {
+		fmt.Fprintf(os.Stderr, "Warning: "+format, args...)
+	}
+}
+
+func logError(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, format, args...)
+}
+
+// page is one file a command writes, or would write, into the destination folder.
+type page struct {
+	Path    string
+	Content []byte
+}
+
+// renderPages renders the headers of the project in the selected format, the second phase of the commands writing pages.
+// Failures are reported and counted.
+func renderPages(opts *options, project *Project) ([]page, int) {
+	var pages []page
+	failures := 0
+
+	if opts.Format == "json" && opts.Combined {
+		content, err := renderJSONIndex(project.Files)
+		if err != nil {
+			logError("Error: %v\n", err)
+			return nil, 1
+		}
+		return []page{{filepath.Join(opts.DestFolder, jsonIndexFileName), content}}, 0
+	}
+
+	// Pages are rendered in parallel into filePages, then collected in the order of the files
+	filePages := make([]page, len(project.Files))
+	errs := make([]error, len(project.Files))
+	forEachParallel(len(project.Files), opts.Jobs, func(i int) {
+		fileInfo := &project.Files[i]
+
+		var pagePath string
+		var content []byte
+		var err error
+		switch opts.Format {
+		case "json":
+			pagePath = filepath.Join(opts.DestFolder, filepath.FromSlash(jsonFileName(fileInfo, &opts.Config)))
+			content, err = renderJSON(fileInfo)
+		default:
+			pagePath = outputPath(fileInfo, opts.DestFolder, &opts.Config)
+			existingPath := pagePath
+			if opts.DestFolder == "" {
+				existingPath = ""
+			}
+			content, err = renderPage(opts.Renderer, fileInfo, existingPath, project)
+		}
+		filePages[i] = page{pagePath, content}
+		errs[i] = err
+	})
+
+	for i := range filePages {
+		if errs[i] != nil {
+			logError("Error: %v\n", errs[i])
+			failures++
+			continue
+		}
+		pages = append(pages, filePages[i])
+	}
+
+	if opts.Format != "json" {
+		titles := map[string]string{}
+		for i := range project.Files {
+			titles[project.PagePath(&project.Files[i])] = project.Files[i].Name
+		}
+
+		indexPath := "index" + opts.Config.Output.Extension
+		if opts.Config.Output.Index {
+			content, err := renderIndex(opts.Renderer, project, indexPath)
+			if err != nil {
+				logError("Error: %v\n", err)
+				failures++
+			} else {
+				titles[indexPath] = indexTitle
+				pages = append(pages, page{filepath.Join(opts.DestFolder, filepath.FromSlash(indexPath)), content})
+			}
+		}
+
+		if opts.Config.Output.Sidebar != "" {
+			manifests, err := sidebarManifests(opts.Config.Output.Sidebar, indexPath, titles)
+			if err != nil {
+				logError("Error: %v\n", err)
+				failures++
+			}
+			for _, manifest := range manifests {
+				pages = append(pages, page{filepath.Join(opts.DestFolder, filepath.FromSlash(manifest.Path)), manifest.Content})
+			}
+		}
+	}
+
+	if opts.Format != "json" && opts.Config.Output.Diagrams {
+		hierarchy := project.hierarchyDiagram()
+		pages = append(pages,
+			page{filepath.Join(opts.DestFolder, hierarchyMermaidFileName), []byte(project.Mermaid(hierarchy, hierarchyMermaidFileName))},
+			page{filepath.Join(opts.DestFolder, hierarchyDotFileName), []byte(project.Dot(hierarchy, hierarchyDotFileName))})
+	}
+	return pages, failures
+}
+
+func runGenerate(opts *options) error {
+	project, failures, err := loadProject(opts)
+	if err != nil {
+		return err
+	}
+
+	if !opts.DryRun {
+		if err := os.MkdirAll(opts.DestFolder, 0755); err != nil {
+			return fmt.Errorf("creating destination folder: %w", err)
+		}
+	}
+
+	pages, renderFailures := renderPages(opts, project)
+	failures += renderFailures
+
+	for _, page := range pages {
+		// Rewriting identical pages would only touch their timestamp and rebuild the documentation site
+		if !opts.Force {
+			if existing, err := os.ReadFile(page.Path); err == nil && bytes.Equal(existing, page.Content) {
+				logDebug("Unchanged %s\n", page.Path)
+				continue
+			}
+		}
+
+		if opts.DryRun {
+			logInfo("Would write %s\n", page.Path)
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(page.Path), 0755); err != nil {
+			logError("Error: creating folder of %s: %v\n", page.Path, err)
+			failures++
+			continue
+		}
+		if err := os.WriteFile(page.Path, page.Content, 0644); err != nil {
+			logError("Error: writing output file %s: %v\n", page.Path, err)
+			failures++
+			continue
+		}
+		logInfo("Generated %s\n", page.Path)
+	}
+
+	if !opts.DryRun {
+		if err := saveCache(opts.DestFolder, project); err != nil {
+			logError("Error: %v\n", err)
+			failures++
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d file(s) failed", failures)
+	}
+	return nil
+}
+
+func runCheck(opts *options) error {
+	project, failures, err := loadProject(opts)
+	if err != nil {
+		return err
+	}
+
+	for i := range project.Files {
+		if len(project.Files[i].Data) == 0 {
+			logWarn("no class, struct or enum found in %s\n", project.Files[i].Path)
+		}
+	}
+
+	_, renderFailures := renderPages(opts, project)
+	failures += renderFailures
+
+	if failures > 0 {
+		return fmt.Errorf("%d problem(s) found", failures)
+	}
+	logInfo("Checked %d file(s), no problem found\n", len(project.Files))
+	return nil
+}
+
+func runList(opts *options) error {
+	project, failures, err := loadProject(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, fileInfo := range project.Files {
+		fmt.Printf("%s\n", fileInfo.Path)
+		for _, data := range fileInfo.Data {
+			fmt.Printf("  %-6s %s (%d properties, %d functions)\n", data.Kind(), data.QualifiedName(), len(data.Properties), len(data.Functions))
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d file(s) failed", failures)
+	}
+	return nil
+}
+
+func runDiff(opts *options) error {
+	project, failures, err := loadProject(opts)
+	if err != nil {
+		return err
+	}
+
+	pages, renderFailures := renderPages(opts, project)
+	failures += renderFailures
+
+	changed := 0
+	for _, page := range pages {
+		existing, err := os.ReadFile(page.Path)
+		if err != nil && !os.IsNotExist(err) {
+			logError("Error: reading %s: %v\n", page.Path, err)
+			failures++
+			continue
+		}
+
+		patch := unifiedDiff(page.Path, string(existing), string(page.Content))
+		if patch != "" {
+			changed++
+			fmt.Print(patch)
+		}
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d file(s) failed", failures)
+	}
+	if changed > 0 {
+		return fmt.Errorf("%d page(s) out of date", changed)
+	}
+	logInfo("All pages up to date\n")
+	return nil
+}
+
+func runCoverage(opts *options) error {
+	project, failures, err := loadProject(opts)
+	if err != nil {
+		return err
+	}
+	report := newCoverageReport(project)
+
+	writer := os.Stdout
+	if opts.ReportPath != "" {
+		writer, err = os.Create(opts.ReportPath)
+		if err != nil {
+			return fmt.Errorf("creating report: %w", err)
+		}
+		defer writer.Close()
+	}
+	switch opts.Report {
+	case "json":
+		err = report.writeJSON(writer)
+	case "junit":
+		err = report.writeJUnit(writer)
+	default:
+		report.writeText(writer)
+	}
+	if err != nil {
+		return fmt.Errorf("writing report: %w", err)
+	}
+	if opts.ReportPath != "" {
+		logInfo("Wrote %s, %s of the public symbols documented\n", opts.ReportPath, strings.TrimSpace(report.Public().String()))
+	}
+
+	if failures > 0 {
+		return fmt.Errorf("%d file(s) failed", failures)
+	}
+	if percent := report.Public().Percent(); percent < opts.MinCoverage {
+		return fmt.Errorf("public documentation coverage %.1f%% is below the minimum of %g%%", percent, opts.MinCoverage)
+	}
+	return nil
+}
+
+func runInit(opts *options) error {
+	configPath := opts.ConfigPath
+	if configPath == "" {
+		configPath = filepath.Join(opts.SourceFolder, configFileNames[0])
+	}
+
+	content, err := yaml.Marshal(&opts.Config)
+	if err != nil {
+		return err
+	}
+	content = append([]byte("# go-cpp-mk config, see README.md for every setting\n"), content...)
+
+	if opts.DryRun {
+		fmt.Print(string(content))
+		return nil
+	}
+
+	if _, err := os.Stat(configPath); err == nil && !opts.Force {
+		return fmt.Errorf("%s already exists, use -force to overwrite it", configPath)
+	}
+
+	if err := os.WriteFile(configPath, content, 0644); err != nil {
+		return fmt.Errorf("writing config: %w", err)
+	}
+	logInfo("Wrote %s\n", configPath)
+	return nil
+}
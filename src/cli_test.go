@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// captureOutput runs f and returns what it wrote to the standard output and the standard error.
+func captureOutput(t *testing.T, f func()) (string, string) {
+	t.Helper()
+	capture := func(file **os.File) func() string {
+		reader, writer, err := os.Pipe()
+		if err != nil {
+			t.Fatal(err)
+		}
+		original := *file
+		*file = writer
+		output := make(chan string)
+		go func() {
+			content, _ := io.ReadAll(reader)
+			output <- string(content)
+		}()
+		return func() string {
+			*file = original
+			writer.Close()
+			return <-output
+		}
+	}
+	stdout := capture(&os.Stdout)
+	stderr := capture(&os.Stderr)
+	f()
+	return stdout(), stderr()
+}
+
+func TestCoverageJSONOutput(t *testing.T) {
+	folder := t.TempDir()
+	files := map[string]string{
+		configFileNames[0]: "include: ['*.h']\noverrides: overrides.yaml\n",
+		"overrides.yaml":   "UTask::Renamed:\n  description: Gone.\n",
+		"Task.h": `UCLASS()
+class UTask : public UObject
+{
+	GENERATED_BODY()
+public:
+	/**
+	 * Runs the task.
+	 * @param Missing Not a parameter.
+	 */
+	UFUNCTION(BlueprintCallable)
+	void Run(int32 Count);
+};
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(folder, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var code int
+	stdout, stderr := captureOutput(t, func() {
+		code = run([]string{"coverage", "-report", "json", folder})
+	})
+	if code != exitOk {
+		t.Errorf("exit code %d, want %d, standard error:\n%s", code, exitOk, stderr)
+	}
+
+	var report map[string]any
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Errorf("standard output is not JSON: %v\n%s", err, stdout)
+	}
+	for _, warning := range []string{"@param Missing does not match", "override UTask::Renamed matches no type"} {
+		if !strings.Contains(stderr, warning) {
+			t.Errorf("standard error misses %q:\n%s", warning, stderr)
+		}
+	}
+}
@@ -0,0 +1,273 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Names looked up in the source folder when no config file is passed explicitly.
+var configFileNames = []string{
+	".go-cpp-mk.yaml",
+	".go-cpp-mk.yml",
+	"go-cpp-mk.yaml",
+	"go-cpp-mk.yml",
+}
+
+type OutputConfig struct {
+	// Format of the generated pages: mdx, md, html, adoc, rst or json. The -format flag takes precedence.
+	Format string `yaml:"format"`
+	// Extension of the generated pages, including the leading dot. Empty means the extension of the format.
+	Extension string `yaml:"extension,omitempty"`
+	// Layout of the pages in the destination folder: mirror (the folders of the headers, after Paths) or flat.
+	Layout string `yaml:"layout"`
+	// Paths rewrite the folders of the headers before they are mirrored, the first matching one applies.
+	Paths []PathMapping `yaml:"paths,omitempty"`
+	// FrontMatter is a text/template executed with the FileInfo of the page.
+	// Its result is written between the two '---' lines at the top of the page.
+	FrontMatter string `yaml:"frontMatter"`
+	// Visibility selects the members documented: public, protected (public and protected) or all.
+	// The -visibility flag takes precedence.
+	Visibility string `yaml:"visibility"`
+	// Diagrams adds a Mermaid inheritance diagram to every type page, and writes the inheritance of the
+	// whole project as hierarchy.mmd and hierarchy.dot.
+	Diagrams bool `yaml:"diagrams"`
+	// Templates is a folder of text/template files replacing the built-in mdx templates of the same name.
+	// Relative paths are resolved against the folder of the config file. The -templates flag takes precedence.
+	Templates string `yaml:"templates,omitempty"`
+	// Index writes a landing page named index, listing every type and header with the first sentence of its documentation.
+	Index bool `yaml:"index"`
+	// Sidebar writes the navigation manifest of a documentation site in every folder of pages:
+	// docusaurus (_category_.json) or nextra (_meta.json). Empty writes none.
+	Sidebar string `yaml:"sidebar,omitempty"`
+}
+
+// PathMapping replaces the folder From, relative to the source folder, with the folder To relative to the destination
+// folder, e.g. From "FlowPilot/Public" To "FlowPilot" drops the Public folder of the pages. An empty To moves the pages
+// to the destination folder itself.
+type PathMapping struct {
+	From string `yaml:"from"`
+	To   string `yaml:"to"`
+}
+
+type Config struct {
+	// Include and Exclude are glob patterns. Patterns without a '/' are matched
+	// against the file name, others against the path relative to the source folder.
+	// '**' matches any number of folders.
+	Include []string `yaml:"include"`
+	Exclude []string `yaml:"exclude"`
+	// Lines starting with any of these prefixes are dropped before parsing.
+	IgnorePrefixes []string `yaml:"ignorePrefixes"`
+	// Overrides is a YAML file of hand written documentation by qualified name, see Override.
+	// Relative paths are resolved against the folder of the config file.
+	Overrides string       `yaml:"overrides,omitempty"`
+	Output    OutputConfig `yaml:"output"`
+}
+
+// DefaultConfig returns the settings used when no config file is found.
+// They match what the tool has always done for FlowPilot.
+func DefaultConfig() Config {
+	return Config{
+		Include: []string{"*.h", "*.hpp"},
+		Exclude: []string{
+			"FlowPilotModule.h",
+			"FlowPilotCustomVersion.h",
+			"FlowPilotDebugUtils.h",
+			"FlowPilotGlobals.h",
+		},
+		IgnorePrefixes: []string{
+			"// UFlowPilotTask",
+			"//~UFlowPilotTask",
+			"DECLARE_MULTICAST_DELEGATE",
+			"// TODO (MA):",
+		},
+		Output: OutputConfig{
+			Format:      "mdx",
+			Layout:      "mirror",
+			Visibility:  "all",
+			FrontMatter: "title: {{.Name}}\ndescription: Reference page for {{.Name}}\n",
+		},
+	}
+}
+
+// findConfig returns the first known config file name present in sourceFolder, or "".
+func findConfig(sourceFolder string) string {
+	for _, name := range configFileNames {
+		configPath := filepath.Join(sourceFolder, name)
+		if info, err := os.Stat(configPath); err == nil && !info.IsDir() {
+			return configPath
+		}
+	}
+	return ""
+}
+
+// loadConfig reads configPath, or discovers a config in sourceFolder when configPath is empty.
+// Settings missing from the file keep their default value.
+func loadConfig(configPath, sourceFolder string) (Config, error) {
+	cfg := DefaultConfig()
+
+	if configPath == "" {
+		configPath = findConfig(sourceFolder)
+		if configPath == "" {
+			return cfg, nil
+		}
+	}
+
+	content, err := os.ReadFile(configPath)
+	if err != nil {
+		return cfg, fmt.Errorf("reading config %s: %w", configPath, err)
+	}
+
+	if err := yaml.Unmarshal(content, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing config %s: %w", configPath, err)
+	}
+
+	if cfg.Output.Extension != "" && !strings.HasPrefix(cfg.Output.Extension, ".") {
+		cfg.Output.Extension = "." + cfg.Output.Extension
+	}
+
+	if cfg.Overrides != "" && !filepath.IsAbs(cfg.Overrides) {
+		cfg.Overrides = filepath.Join(filepath.Dir(configPath), cfg.Overrides)
+	}
+
+	if cfg.Output.Templates != "" && !filepath.IsAbs(cfg.Output.Templates) {
+		cfg.Output.Templates = filepath.Join(filepath.Dir(configPath), cfg.Output.Templates)
+	}
+
+	if _, err := template.New("frontMatter").Parse(cfg.Output.FrontMatter); err != nil {
+		return cfg, fmt.Errorf("parsing frontMatter template in %s: %w", configPath, err)
+	}
+
+	if cfg.Output.Layout != "mirror" && cfg.Output.Layout != "flat" {
+		return cfg, fmt.Errorf("unknown layout %q in %s, expected mirror or flat", cfg.Output.Layout, configPath)
+	}
+
+	if _, ok := sidebarManifestNames[cfg.Output.Sidebar]; cfg.Output.Sidebar != "" && !ok {
+		return cfg, fmt.Errorf("unknown sidebar %q in %s, expected docusaurus or nextra", cfg.Output.Sidebar, configPath)
+	}
+
+	return cfg, nil
+}
+
+// Values of output.visibility and -visibility, with the least visible access level each one keeps.
+var visibilities = map[string]AccessType{
+	"public":    Public,
+	"protected": Protected,
+	"all":       Private,
+}
+
+// MaxAccess returns the least visible access level documented with the configured visibility.
+func (c *Config) MaxAccess() (AccessType, error) {
+	maxAccess, ok := visibilities[c.Output.Visibility]
+	if !ok {
+		return Private, fmt.Errorf("unknown visibility %q, expected public, protected or all", c.Output.Visibility)
+	}
+	return maxAccess, nil
+}
+
+// ShouldProcess reports whether the file at relPath (relative to the source folder) is included and not excluded.
+func (c *Config) ShouldProcess(relPath string) bool {
+	relPath = filepath.ToSlash(relPath)
+	return matchesAny(c.Include, relPath) && !matchesAny(c.Exclude, relPath)
+}
+
+// IsIgnoredLine reports whether a trimmed source line starts with one of the ignore prefixes.
+func (c *Config) IsIgnoredLine(line string) bool {
+	for _, prefix := range c.IgnorePrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// PagePath returns the page of the header at relPath, relative to the source folder, with extension instead of the
+// one of the header: the same path with the mirror layout, the file name only with the flat one, e.g.
+// Public/Tasks/Foo.h -> Public/Tasks/Foo.mdx. Both are relative to the destination folder, with forward slashes.
+func (c *Config) PagePath(relPath string, extension string) string {
+	pagePath := c.mapPath(relPath)
+	if c.Output.Layout == "flat" {
+		pagePath = path.Base(pagePath)
+	}
+	return strings.TrimSuffix(pagePath, path.Ext(pagePath)) + extension
+}
+
+// mapPath applies the first of Output.Paths whose From folder holds relPath.
+func (c *Config) mapPath(relPath string) string {
+	for _, mapping := range c.Output.Paths {
+		from := strings.Trim(filepath.ToSlash(mapping.From), "/")
+		if from == "" {
+			return path.Join(filepath.ToSlash(mapping.To), relPath)
+		}
+		if rest, ok := strings.CutPrefix(relPath, from+"/"); ok {
+			return path.Join(filepath.ToSlash(mapping.To), rest)
+		}
+	}
+	return relPath
+}
+
+// FrontMatter executes the front matter template for fileInfo.
+func (c *Config) FrontMatter(fileInfo *FileInfo) (string, error) {
+	tmpl, err := template.New("frontMatter").Parse(c.Output.FrontMatter)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	if err := tmpl.Execute(&buffer, fileInfo); err != nil {
+		return "", err
+	}
+
+	frontMatter := buffer.String()
+	if frontMatter != "" && !strings.HasSuffix(frontMatter, "\n") {
+		frontMatter += "\n"
+	}
+	return frontMatter, nil
+}
+
+func matchesAny(patterns []string, relPath string) bool {
+	for _, pattern := range patterns {
+		pattern = filepath.ToSlash(pattern)
+		if !strings.Contains(pattern, "/") {
+			if ok, _ := path.Match(pattern, path.Base(relPath)); ok {
+				return true
+			}
+			continue
+		}
+		if matchGlob(strings.Split(pattern, "/"), strings.Split(relPath, "/")) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchGlob matches path segments against pattern segments, where a "**" segment matches zero or more folders.
+func matchGlob(pattern, parts []string) bool {
+	if len(pattern) == 0 {
+		return len(parts) == 0
+	}
+
+	if pattern[0] == "**" {
+		for i := 0; i <= len(parts); i++ {
+			if matchGlob(pattern[1:], parts[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(parts) == 0 {
+		return false
+	}
+
+	if ok, _ := path.Match(pattern[0], parts[0]); !ok {
+		return false
+	}
+	return matchGlob(pattern[1:], parts[1:])
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestShouldProcess(t *testing.T) {
+	for _, test := range []struct {
+		include, exclude []string
+		relPath          string
+		want             bool
+	}{
+		// Patterns without '/' match the file name in any folder
+		{[]string{"*.h"}, nil, "Foo.h", true},
+		{[]string{"*.h"}, nil, "Public/Tasks/Foo.h", true},
+		{[]string{"*.h"}, nil, "Public/Foo.cpp", false},
+		// Patterns with '/' are anchored at the source folder
+		{[]string{"Public/*.h"}, nil, "Public/Foo.h", true},
+		{[]string{"Public/*.h"}, nil, "Public/Tasks/Foo.h", false},
+		{[]string{"Public/*.h"}, nil, "Plugin/Public/Foo.h", false},
+		// '**' matches zero or more folders
+		{[]string{"Public/**/*.h"}, nil, "Public/Foo.h", true},
+		{[]string{"Public/**/*.h"}, nil, "Public/Tasks/Deep/Foo.h", true},
+		{[]string{"**/Public/*.h"}, nil, "Plugin/Public/Foo.h", true},
+		{[]string{"**/Public/*.h"}, nil, "Public/Foo.h", true},
+		{[]string{"**/Public/*.h"}, nil, "Private/Foo.h", false},
+		// Exclude wins over include
+		{[]string{"*.h"}, []string{"Private/**"}, "Private/Impl/Foo.h", false},
+		{[]string{"*.h"}, []string{"Private/**"}, "Public/Private.h", true},
+		{[]string{"*.h"}, []string{"*Module.h"}, "Public/FooModule.h", false},
+		{[]string{"Public/**"}, []string{"**/Tests/*"}, "Public/Tests/FooTest.h", false},
+		{nil, nil, "Foo.h", false},
+	} {
+		cfg := Config{Include: test.include, Exclude: test.exclude}
+		if got := cfg.ShouldProcess(test.relPath); got != test.want {
+			t.Errorf("include %q, exclude %q: ShouldProcess(%q) = %v, want %v", test.include, test.exclude, test.relPath, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// coverageFormats are the reports written by coverage, selected with -report.
+var coverageFormats = []string{"text", "json", "junit"}
+
+// coverageCount is the number of documented symbols among Total.
+type coverageCount struct {
+	Documented int `json:"documented"`
+	Total      int `json:"total"`
+}
+
+func (c *coverageCount) add(documented bool) {
+	c.Total++
+	if documented {
+		c.Documented++
+	}
+}
+
+// Percent returns the documented share of the symbols, 100 when there are none.
+func (c coverageCount) Percent() float64 {
+	if c.Total == 0 {
+		return 100
+	}
+	return float64(c.Documented) * 100 / float64(c.Total)
+}
+
+func (c coverageCount) String() string {
+	return fmt.Sprintf("%5.1f%% (%d/%d)", c.Percent(), c.Documented, c.Total)
+}
+
+func (c coverageCount) MarshalJSON() ([]byte, error) {
+	type count coverageCount
+	return json.Marshal(struct {
+		count
+		Percent float64 `json:"percent"`
+	}{count(c), c.Percent()})
+}
+
+// coverageTotals counts the types, UPROPERTYs and UFUNCTIONs of a file, a type or a whole project.
+type coverageTotals struct {
+	All        coverageCount `json:"all"`
+	Types      coverageCount `json:"types"`
+	Properties coverageCount `json:"properties"`
+	Functions  coverageCount `json:"functions"`
+}
+
+func (t *coverageTotals) add(symbol *coverageSymbol) {
+	t.All.add(symbol.Documented)
+	switch symbol.Kind {
+	case "property":
+		t.Properties.add(symbol.Documented)
+	case "function":
+		t.Functions.add(symbol.Documented)
+	default:
+		t.Types.add(symbol.Documented)
+	}
+}
+
+// coverageSymbol is one symbol the report counts: a type, a UPROPERTY or a UFUNCTION.
+type coverageSymbol struct {
+	// Kind is "class", "struct", "enum", "property" or "function"
+	Kind string `json:"kind"`
+	// Name is qualified by the type for members, e.g. 'UFlowPilotTask::Enter'
+	Name       string     `json:"name"`
+	Access     AccessType `json:"access"`
+	Line       int        `json:"line"`
+	Documented bool       `json:"documented"`
+}
+
+type typeCoverage struct {
+	Name    string           `json:"name"`
+	Kind    string           `json:"kind"`
+	Totals  coverageTotals   `json:"totals"`
+	Symbols []coverageSymbol `json:"symbols"`
+}
+
+type fileCoverage struct {
+	RelPath string         `json:"relPath"`
+	Totals  coverageTotals `json:"totals"`
+	Types   []typeCoverage `json:"types"`
+}
+
+// coverageReport is the documentation coverage of a project. Types have no access level and are counted as public.
+type coverageReport struct {
+	Totals   coverageTotals            `json:"totals"`
+	ByAccess map[string]coverageTotals `json:"byAccess"`
+	Files    []fileCoverage            `json:"files"`
+}
+
+// newCoverageReport counts the documented types of the project and their UPROPERTYs and UFUNCTIONs. Members without
+// the macros are not exposed to Blueprint and are left out. ToolTip metas and overrides count as documentation.
+func newCoverageReport(project *Project) *coverageReport {
+	report := &coverageReport{ByAccess: map[string]coverageTotals{}}
+	for i := range project.Files {
+		fileInfo := &project.Files[i]
+		file := fileCoverage{RelPath: fileInfo.RelPath}
+		for j := range fileInfo.Data {
+			d := &fileInfo.Data[j]
+			typeReport := typeCoverage{Name: d.ScopedName(), Kind: d.Kind()}
+			typeReport.Symbols = append(typeReport.Symbols, coverageSymbol{d.Kind(), d.ScopedName(), Public, d.Line, len(d.Documentation()) > 0})
+			for _, prop := range d.Properties {
+				if strings.HasPrefix(prop.Macro, "UPROPERTY") {
+					typeReport.Symbols = append(typeReport.Symbols, coverageSymbol{"property", d.ScopedName() + "::" + propertyName(prop.Declaration), prop.Access, prop.Line, len(prop.Documentation()) > 0})
+				}
+			}
+			for _, function := range d.Functions {
+				if strings.HasPrefix(function.Macro, "UFUNCTION") {
+					typeReport.Symbols = append(typeReport.Symbols, coverageSymbol{"function", d.ScopedName() + "::" + function.Name, function.Access, function.Line, len(function.Documentation()) > 0})
+				}
+			}
+
+			for k := range typeReport.Symbols {
+				symbol := &typeReport.Symbols[k]
+				typeReport.Totals.add(symbol)
+				file.Totals.add(symbol)
+				report.Totals.add(symbol)
+				access := strings.ToLower(accessModifierString(symbol.Access))
+				totals := report.ByAccess[access]
+				totals.add(symbol)
+				report.ByAccess[access] = totals
+			}
+			file.Types = append(file.Types, typeReport)
+		}
+		report.Files = append(report.Files, file)
+	}
+	return report
+}
+
+// Public returns the count of the public symbols, the share -min-coverage applies to.
+func (r *coverageReport) Public() coverageCount {
+	return r.ByAccess[strings.ToLower(accessModifierString(Public))].All
+}
+
+// writeText writes the coverage of every file and type, the undocumented symbols, then the totals.
+func (r *coverageReport) writeText(writer io.Writer) {
+	for _, file := range r.Files {
+		fmt.Fprintf(writer, "%-50s %s\n", file.RelPath, file.Totals.All)
+		for _, typeReport := range file.Types {
+			fmt.Fprintf(writer, "  %-48s %s\n", typeReport.Name, typeReport.Totals.All)
+			for _, symbol := range typeReport.Symbols {
+				if !symbol.Documented {
+					fmt.Fprintf(writer, "    undocumented %s %s (line %d)\n", symbol.Kind, symbol.Name, symbol.Line)
+				}
+			}
+		}
+	}
+
+	fmt.Fprintln(writer)
+	for _, access := range []AccessType{Public, Protected, Private} {
+		name := strings.ToLower(accessModifierString(access))
+		if totals, ok := r.ByAccess[name]; ok {
+			fmt.Fprintf(writer, "%-50s %s\n", name, totals.All)
+		}
+	}
+	fmt.Fprintf(writer, "%-50s %s\n", "types", r.Totals.Types)
+	fmt.Fprintf(writer, "%-50s %s\n", "properties", r.Totals.Properties)
+	fmt.Fprintf(writer, "%-50s %s\n", "functions", r.Totals.Functions)
+	fmt.Fprintf(writer, "%-50s %s\n", "total", r.Totals.All)
+}
+
+func (r *coverageReport) writeJSON(writer io.Writer) error {
+	content, err := marshalJSON(r)
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(content)
+	return err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	ClassName string        `xml:"classname,attr"`
+	Name      string        `xml:"name,attr"`
+	Failure   *junitFailure `xml:"failure"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// writeJUnit writes one test suite per header and one test case per symbol, failing when the symbol is undocumented,
+// so CI servers list the missing documentation like failed tests.
+func (r *coverageReport) writeJUnit(writer io.Writer) error {
+	suites := junitTestSuites{Name: "documentation coverage", Tests: r.Totals.All.Total, Failures: r.Totals.All.Total - r.Totals.All.Documented}
+	for _, file := range r.Files {
+		suite := junitTestSuite{Name: file.RelPath, Tests: file.Totals.All.Total, Failures: file.Totals.All.Total - file.Totals.All.Documented}
+		for _, typeReport := range file.Types {
+			for _, symbol := range typeReport.Symbols {
+				testCase := junitTestCase{ClassName: file.RelPath, Name: symbol.Name}
+				if !symbol.Documented {
+					testCase.Failure = &junitFailure{
+						Message: fmt.Sprintf("undocumented %s", symbol.Kind),
+						Text:    fmt.Sprintf("%s:%d: %s %s has no doc comment", file.RelPath, symbol.Line, symbol.Kind, symbol.Name),
+					}
+				}
+				suite.Cases = append(suite.Cases, testCase)
+			}
+		}
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	content, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	if _, err := io.WriteString(writer, xml.Header); err != nil {
+		return err
+	}
+	_, err = writer.Write(append(content, '\n'))
+	return err
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+	"testing"
+)
+
+const coverageSource = `/** A documented task. */
+UCLASS()
+class UTask : public UObject
+{
+	GENERATED_BODY()
+public:
+	/** Speed of the task. */
+	UPROPERTY(EditAnywhere)
+	float Speed;
+
+	UPROPERTY(EditAnywhere)
+	float Delay;
+
+	UFUNCTION(BlueprintCallable, meta = (ToolTip = "Runs the task"))
+	void Run();
+
+	// Not exposed, not counted
+	int32 Counter;
+	void Helper();
+
+protected:
+	UFUNCTION(BlueprintNativeEvent)
+	void OnRun();
+
+private:
+	/** Owner of the task. */
+	UPROPERTY()
+	UObject* Owner;
+};
+
+UENUM()
+enum class EState : uint8 { Idle, Running };
+`
+
+// coverageProject returns a project of one header per source.
+func coverageProject(sources ...string) *Project {
+	var files []FileInfo
+	for i, source := range sources {
+		fileInfo := FileInfo{RelPath: "Public/Task" + string(rune('A'+i)) + ".h", Name: "Task" + string(rune('A'+i)) + ".h"}
+		parseHeader(source, &fileInfo)
+		files = append(files, fileInfo)
+	}
+	cfg := DefaultConfig()
+	return newProject("", &cfg, files)
+}
+
+func TestCoverageReport(t *testing.T) {
+	report := newCoverageReport(coverageProject(coverageSource))
+
+	want := coverageTotals{
+		All:        coverageCount{Documented: 4, Total: 7},
+		Types:      coverageCount{Documented: 1, Total: 2},
+		Properties: coverageCount{Documented: 2, Total: 3},
+		Functions:  coverageCount{Documented: 1, Total: 2},
+	}
+	if report.Totals != want {
+		t.Errorf("totals %+v, want %+v", report.Totals, want)
+	}
+	if len(report.Files) != 1 || report.Files[0].Totals != want {
+		t.Errorf("file totals %+v, want %+v", report.Files, want)
+	}
+
+	byAccess := map[string]coverageCount{}
+	for access, totals := range report.ByAccess {
+		byAccess[access] = totals.All
+	}
+	wantByAccess := map[string]coverageCount{
+		"public":    {Documented: 3, Total: 5},
+		"protected": {Documented: 0, Total: 1},
+		"private":   {Documented: 1, Total: 1},
+	}
+	if !reflect.DeepEqual(byAccess, wantByAccess) {
+		t.Errorf("by access %+v, want %+v", byAccess, wantByAccess)
+	}
+	if got := report.Public().Percent(); got != 60 {
+		t.Errorf("public coverage %g%%, want 60%%", got)
+	}
+
+	var undocumented []string
+	for _, typeReport := range report.Files[0].Types {
+		for _, symbol := range typeReport.Symbols {
+			if !symbol.Documented {
+				undocumented = append(undocumented, symbol.Kind+" "+symbol.Name)
+			}
+		}
+	}
+	if want := []string{"property UTask::Delay", "function UTask::OnRun", "enum EState"}; !reflect.DeepEqual(undocumented, want) {
+		t.Errorf("undocumented %q, want %q", undocumented, want)
+	}
+}
+
+func TestCoverageReportEmpty(t *testing.T) {
+	for _, project := range []*Project{coverageProject(), coverageProject("#pragma once\n")} {
+		report := newCoverageReport(project)
+		if got := report.Totals.All.Percent(); got != 100 {
+			t.Errorf("%d files: coverage %g%%, want 100%%", len(project.Files), got)
+		}
+		if got := report.Public().Percent(); got != 100 {
+			t.Errorf("%d files: public coverage %g%%, want 100%%", len(project.Files), got)
+		}
+	}
+}
+
+func TestCoverageReportJSON(t *testing.T) {
+	report := newCoverageReport(coverageProject(coverageSource, "class FEmpty {};"))
+	var buffer bytes.Buffer
+	if err := report.writeJSON(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	var parsed struct {
+		Totals struct {
+			All struct {
+				Documented int     `json:"documented"`
+				Total      int     `json:"total"`
+				Percent    float64 `json:"percent"`
+			} `json:"all"`
+		} `json:"totals"`
+		ByAccess map[string]json.RawMessage `json:"byAccess"`
+		Files    []struct {
+			RelPath string `json:"relPath"`
+			Types   []struct {
+				Name    string `json:"name"`
+				Symbols []struct {
+					Name       string `json:"name"`
+					Documented bool   `json:"documented"`
+				} `json:"symbols"`
+			} `json:"types"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(buffer.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, buffer.String())
+	}
+	if parsed.Totals.All.Documented != 4 || parsed.Totals.All.Total != 8 || parsed.Totals.All.Percent != 50 {
+		t.Errorf("totals %+v, want 4 of 8", parsed.Totals.All)
+	}
+	if len(parsed.ByAccess) != 3 {
+		t.Errorf("got %d access levels, want 3", len(parsed.ByAccess))
+	}
+	if len(parsed.Files) != 2 || parsed.Files[0].RelPath != "Public/TaskA.h" || len(parsed.Files[0].Types) != 2 || len(parsed.Files[0].Types[0].Symbols) != 6 {
+		t.Errorf("files %+v", parsed.Files)
+	}
+}
+
+func TestCoverageReportJUnit(t *testing.T) {
+	report := newCoverageReport(coverageProject(coverageSource, "class FEmpty {};"))
+	var buffer bytes.Buffer
+	if err := report.writeJUnit(&buffer); err != nil {
+		t.Fatal(err)
+	}
+
+	var parsed junitTestSuites
+	if err := xml.Unmarshal(buffer.Bytes(), &parsed); err != nil {
+		t.Fatalf("invalid XML: %v\n%s", err, buffer.String())
+	}
+	if parsed.Tests != 8 || parsed.Failures != 4 || len(parsed.Suites) != 2 {
+		t.Fatalf("%d tests, %d failures, %d suites, want 8, 4 and 2", parsed.Tests, parsed.Failures, len(parsed.Suites))
+	}
+
+	suite := parsed.Suites[0]
+	if suite.Name != "Public/TaskA.h" || suite.Tests != 7 || suite.Failures != 3 || len(suite.Cases) != 7 {
+		t.Errorf("suite %s: %d tests, %d failures, %d cases, want Public/TaskA.h with 7, 3 and 7", suite.Name, suite.Tests, suite.Failures, len(suite.Cases))
+	}
+	var failed []string
+	for _, testCase := range suite.Cases {
+		if testCase.Failure != nil {
+			failed = append(failed, testCase.Name+": "+testCase.Failure.Text)
+		}
+	}
+	want := []string{
+		"UTask::Delay: Public/TaskA.h:12: property UTask::Delay has no doc comment",
+		"UTask::OnRun: Public/TaskA.h:23: function UTask::OnRun has no doc comment",
+		"EState: Public/TaskA.h:32: enum EState has no doc comment",
+	}
+	if !reflect.DeepEqual(failed, want) {
+		t.Errorf("failed cases\n got %q\nwant %q", failed, want)
+	}
+}
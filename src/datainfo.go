@@ -1,7 +1,6 @@
 package main
 
 import (
-	"bufio"
 	"fmt"
 	"strings"
 )
@@ -15,120 +14,122 @@ const (
 )
 
 type PropertyInfo struct {
-	Macro       string
-	Declaration string
-	Comments    []string
-	Access      AccessType
+	Macro       string     `json:"macro"`
+	Specifiers  Specifiers `json:"specifiers"`
+	Declaration string     `json:"declaration"`
+	Comments    []string   `json:"comments"`
+	Access      AccessType `json:"access"`
+	Line        int        `json:"line"`
 }
 
 type FunctionInfo struct {
-	Name        string
-	Macro       string
-	Declaration string
-	Comments    []string
-	Access      AccessType
+	Name        string     `json:"name"`
+	Macro       string     `json:"macro"`
+	Specifiers  Specifiers `json:"specifiers"`
+	Declaration string     `json:"declaration"`
+	Comments    []string   `json:"comments"`
+	Access      AccessType `json:"access"`
+	Line        int        `json:"line"`
+	// Signature is Declaration split into its parts
+	Signature Signature `json:"signature"`
+	// Example is C++ code from the overrides, ending with a newline, or ""
+	Example string `json:"example,omitempty"`
 }
 
 type DataInfo struct {
-	Name       string
-	Parents    []string
-	Comments   []string
-	Properties []PropertyInfo
-	Functions  []FunctionInfo
-	IsStruct   bool
-	IsEnum     bool
+	Name string `json:"name"`
+	// Namespace encloses the type, e.g. 'FlowPilot::Detail', or ""
+	Namespace string `json:"namespace,omitempty"`
+	// Outer is the scoped name of the type declaring this nested type, or ""
+	Outer string `json:"outer,omitempty"`
+	// UCLASS, USTRUCT or UENUM call in front of the type, or ""
+	Macro      string         `json:"macro"`
+	Specifiers Specifiers     `json:"specifiers"`
+	Parents    []string       `json:"parents"`
+	Comments   []string       `json:"comments"`
+	Properties []PropertyInfo `json:"properties"`
+	Functions  []FunctionInfo `json:"functions"`
+	IsStruct   bool           `json:"isStruct"`
+	IsEnum     bool           `json:"isEnum"`
+	Line       int            `json:"line"`
+	// Example is C++ code from the overrides, ending with a newline, or ""
+	Example string `json:"example,omitempty"`
 }
 
-func (d *DataInfo) OutputHeader(writer *bufio.Writer) {
-	writer.WriteString("\n")
-	writer.WriteString(fmt.Sprintf("\n## `" + d.Name + "` \n\n"))
+// MarshalText writes the access level as "public", "protected" or "private".
+func (a AccessType) MarshalText() ([]byte, error) {
+	return []byte(strings.ToLower(accessModifierString(a))), nil
 }
 
-func (d *DataInfo) OutputEnumHeader(writer *bufio.Writer) {
-	writer.WriteString("\n")
-	writer.WriteString(fmt.Sprintf("\n### `" + d.Name + "` \n\n"))
+// UnmarshalText reads the access levels written by MarshalText, e.g. from the parse cache.
+func (a *AccessType) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "public":
+		*a = Public
+	case "protected":
+		*a = Protected
+	case "private":
+		*a = Private
+	default:
+		return fmt.Errorf("unknown access level %q", text)
+	}
+	return nil
 }
 
-func (d *DataInfo) OutputParents(writer *bufio.Writer) {
-	if len(d.Parents) > 0 {
-		writer.WriteString("\n")
-		writer.WriteString("__Parent Classes:__\n")
-		writer.WriteString("[ ")
-		for i, parent := range d.Parents {
-			isLast := i == len(d.Parents)-1
-			if !isLast {
-				writer.WriteString(fmt.Sprintf("`%s`, ", parent))
-			} else {
-				writer.WriteString(fmt.Sprintf("`%s`", parent))
-			}
-		}
-		writer.WriteString(" ]\n")
+// Kind returns "enum", "struct" or "class".
+func (d *DataInfo) Kind() string {
+	if d.IsEnum {
+		return "enum"
+	} else if d.IsStruct {
+		return "struct"
 	}
+	return "class"
 }
 
-func (d *DataInfo) OutputDescription(writer *bufio.Writer) {
-	if len(d.Comments) > 0 {
-		writer.WriteString("\n")
-		for _, com := range d.Comments {
-			writer.WriteString("" + cleanComment(com) + " \n")
-		}
-	}
+// ScopedName returns the name of the type qualified by the types enclosing it, e.g. 'UFlowPilotTask::FState'.
+// Types are documented under this name.
+func (d *DataInfo) ScopedName() string {
+	return joinScope(d.Outer, d.Name)
 }
 
-func (d *DataInfo) OutputProperties(writer *bufio.Writer) {
-	if d.HasDocumentedProperties() {
-		writer.WriteString("\n")
-		writer.WriteString("### Properties\n\n")
-
-		writer.WriteString("```cpp\n")
-		for _, prop := range d.Properties {
-			if len(prop.Comments) == 0 {
-				continue
-			}
-			for _, comm := range prop.Comments {
-				writer.WriteString("// " + cleanComment(comm) + " \n")
-			}
-			if prop.Macro != "" {
-				writer.WriteString(prop.Macro + "\n")
-			}
-			writer.WriteString(prop.Declaration + "\n\n")
-		}
-		writer.WriteString("```\n")
-	}
+// QualifiedName returns the scoped name of the type qualified by its namespace.
+func (d *DataInfo) QualifiedName() string {
+	return joinScope(d.Namespace, d.ScopedName())
 }
 
-func (d *DataInfo) OutputFunctions(writer *bufio.Writer) {
-	if d.HasDocumentedFunctions() {
-		writer.WriteString("\n")
-		writer.WriteString("### Functions\n\n")
-
-		for _, function := range d.Functions {
-			if len(function.Comments) == 0 {
-				continue
-			}
-			writer.WriteString("#### `" + function.Name + "`\n")
-			for i, comm := range function.Comments {
-				isLast := i == len(function.Comments)-1
-				if isLast {
-					writer.WriteString("> " + cleanComment(comm) + " \n")
-				} else {
-					writer.WriteString("> " + cleanComment(comm) + " \\\n")
-				}
-			}
-			writer.WriteString("```cpp\n")
-			writer.WriteString(function.Declaration + "\n")
-			writer.WriteString("```\n")
-		}
+// joinScope returns name qualified by scope, or name alone when scope is "" and scope alone when name is "".
+func joinScope(scope, name string) string {
+	if scope == "" {
+		return name
+	}
+	if name == "" {
+		return scope
 	}
+	return scope + "::" + name
+}
+
+// Documentation returns the comments of the property, or its ToolTip meta when it has none.
+func (p *PropertyInfo) Documentation() []string {
+	return documentation(p.Comments, p.Specifiers)
+}
+
+// Documentation returns the comments of the function, or its ToolTip meta when it has none.
+func (f *FunctionInfo) Documentation() []string {
+	return documentation(f.Comments, f.Specifiers)
+}
+
+// Documentation returns the comments of the type, or its ToolTip meta when it has none.
+func (d *DataInfo) Documentation() []string {
+	return documentation(d.Comments, d.Specifiers)
 }
 
 func (d *DataInfo) HasDocumentation() bool {
-	return len(d.Comments) > 0 || d.HasDocumentedProperties() || d.HasDocumentedFunctions()
+	return len(d.Documentation()) > 0 || d.HasDocumentedProperties() || d.HasDocumentedFunctions()
 }
 
 func (d *DataInfo) HasDocumentedProperties() bool {
 	for _, prop := range d.Properties {
-		if len(prop.Comments) > 0 {
+		if len(prop.Documentation()) > 0 {
 			return true
 		}
 	}
@@ -137,29 +138,9 @@ func (d *DataInfo) HasDocumentedProperties() bool {
 
 func (d *DataInfo) HasDocumentedFunctions() bool {
 	for _, function := range d.Functions {
-		if len(function.Comments) > 0 {
+		if len(function.Documentation()) > 0 {
 			return true
 		}
 	}
 	return false
 }
-
-func (d *DataInfo) OutputEnumInfo(writer *bufio.Writer) {
-	writer.WriteString("\n")
-	writer.WriteString("| Value | Description | \n")
-	writer.WriteString("| :-- | :-- | \n")
-
-	for _, prop := range d.Properties {
-		mergedComment := strings.Join(prop.Comments, "\n")
-		if len(mergedComment) == 0 {
-			writer.WriteString("| `" + strings.TrimRight(prop.Declaration, ",") + "` | " + strings.TrimRight(prop.Declaration, ",") + " | \n")
-		} else {
-			comment := []string{}
-			for _, comm := range prop.Comments {
-				comment = append(comment, cleanComment(comm))
-			}
-			writer.WriteString("| `" + strings.TrimRight(prop.Declaration, ",") + "` | " + strings.Join(comment, ", ") + " | \n")
-		}
-	}
-	writer.WriteString("\n")
-}
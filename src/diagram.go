@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Files written next to the pages with the inheritance of the whole project.
+const (
+	hierarchyMermaidFileName = "hierarchy.mmd"
+	hierarchyDotFileName     = "hierarchy.dot"
+)
+
+type diagramEdge struct {
+	Parent string
+	Child  string
+}
+
+// diagram is an inheritance graph. Nodes are the qualified names of the types of the project, and the names of the
+// other parents as written, without template arguments. See Project.parentName.
+type diagram struct {
+	Nodes []string
+	Edges []diagramEdge
+}
+
+func (dg *diagram) addNode(name string) {
+	if !slices.Contains(dg.Nodes, name) {
+		dg.Nodes = append(dg.Nodes, name)
+	}
+}
+
+func (dg *diagram) addEdge(parent, child string) {
+	edge := diagramEdge{Parent: parent, Child: child}
+	if !slices.Contains(dg.Edges, edge) {
+		dg.addNode(parent)
+		dg.addNode(child)
+		dg.Edges = append(dg.Edges, edge)
+	}
+}
+
+// typeDiagram returns the ancestors of d, up to the first types not parsed in the project, and its direct children.
+// It is empty when d has neither parent nor child.
+func (p *Project) typeDiagram(d *DataInfo) diagram {
+	var dg diagram
+	if d.IsEnum {
+		return dg
+	}
+
+	queue := []*DataInfo{d}
+	seen := map[string]bool{d.QualifiedName(): true}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, parent := range current.Parents {
+			name := p.parentName(current, parent)
+			dg.addEdge(name, current.QualifiedName())
+			if parentType, ok := p.types[name]; ok && !seen[name] {
+				seen[name] = true
+				queue = append(queue, parentType)
+			}
+		}
+	}
+
+	for _, child := range p.children[d.QualifiedName()] {
+		dg.addEdge(d.QualifiedName(), child.QualifiedName())
+	}
+	return dg
+}
+
+// hierarchyDiagram returns the inheritance of every class and struct of the project.
+func (p *Project) hierarchyDiagram() diagram {
+	var dg diagram
+	for i := range p.Files {
+		for _, data := range p.Files[i].Data {
+			if data.IsEnum || data.Name == "" {
+				continue
+			}
+			dg.addNode(data.QualifiedName())
+			for _, parent := range data.Parents {
+				dg.addEdge(p.parentName(&data, parent), data.QualifiedName())
+			}
+		}
+	}
+	return dg
+}
+
+// isExternal reports whether a diagram node is not declared by the project, e.g. the UE base classes.
+func (p *Project) isExternal(name string) bool {
+	_, ok := p.types[name]
+	return !ok
+}
+
+// nodeHref returns the link to the section of a node from the file fromPage, or "" for undocumented types.
+func (p *Project) nodeHref(name, fromPage string) string {
+	if d, ok := p.types[name]; !ok || !d.HasDocumentation() {
+		return ""
+	}
+	if symbol, ok := p.Symbols.Lookup(name); ok {
+		return p.Symbols.Href(fromPage, symbol)
+	}
+	return ""
+}
+
+// mermaidID returns the Mermaid class id of a node, whose names cannot hold '::': 'X::A' -> 'X_A'.
+func mermaidID(node string) string {
+	return strings.ReplaceAll(node, "::", "_")
+}
+
+// Mermaid returns dg as a Mermaid classDiagram. Links are relative to the file fromPage.
+func (p *Project) Mermaid(dg diagram, fromPage string) string {
+	var builder strings.Builder
+	builder.WriteString("classDiagram\n")
+	for _, node := range dg.Nodes {
+		if id := mermaidID(node); id != node {
+			builder.WriteString("    class " + id + "[\"" + node + "\"]\n")
+		} else {
+			builder.WriteString("    class " + node + "\n")
+		}
+	}
+	for _, edge := range dg.Edges {
+		builder.WriteString("    " + mermaidID(edge.Parent) + " <|-- " + mermaidID(edge.Child) + "\n")
+	}
+	for _, node := range dg.Nodes {
+		if p.isExternal(node) {
+			builder.WriteString("    <<external>> " + mermaidID(node) + "\n")
+		} else if href := p.nodeHref(node, fromPage); href != "" {
+			builder.WriteString("    click " + mermaidID(node) + " href \"" + href + "\"\n")
+		}
+	}
+	return builder.String()
+}
+
+// Dot returns dg as a Graphviz digraph, external types dashed and grey. Links are relative to the file fromPage.
+func (p *Project) Dot(dg diagram, fromPage string) string {
+	var builder strings.Builder
+	builder.WriteString("digraph hierarchy {\n")
+	builder.WriteString("    rankdir=BT;\n")
+	builder.WriteString("    node [shape=box, fontname=\"Helvetica\"];\n")
+	for _, node := range dg.Nodes {
+		var attributes []string
+		if p.isExternal(node) {
+			attributes = append(attributes, "style=dashed", "color=gray50", "fontcolor=gray50")
+		} else if href := p.nodeHref(node, fromPage); href != "" {
+			attributes = append(attributes, fmt.Sprintf("URL=%q", href))
+		}
+		builder.WriteString(fmt.Sprintf("    %q", node))
+		if len(attributes) > 0 {
+			builder.WriteString(" [" + strings.Join(attributes, ", ") + "]")
+		}
+		builder.WriteString(";\n")
+	}
+	for _, edge := range dg.Edges {
+		builder.WriteString(fmt.Sprintf("    %q -> %q [arrowhead=empty];\n", edge.Child, edge.Parent))
+	}
+	builder.WriteString("}\n")
+	return builder.String()
+}
+
+// TypeMermaid returns the Mermaid diagram of d for its page, or "" when diagrams are disabled or d has no relation.
+func (p *Project) TypeMermaid(d *DataInfo, page string) string {
+	if !p.Config.Output.Diagrams {
+		return ""
+	}
+	dg := p.typeDiagram(d)
+	if len(dg.Edges) == 0 {
+		return ""
+	}
+	return p.Mermaid(dg, page)
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Lines of unchanged context printed around each change.
+const diffContext = 3
+
+type diffOp struct {
+	Kind byte // ' ', '-' or '+'
+	Line string
+}
+
+// unifiedDiff returns the changes from before to after in unified diff format, or "" when they are equal.
+func unifiedDiff(name, before, after string) string {
+	if before == after {
+		return ""
+	}
+
+	ops := diffLines(splitLines(before), splitLines(after))
+
+	var builder strings.Builder
+	builder.WriteString("--- " + name + "\n")
+	builder.WriteString("+++ " + name + " (generated)\n")
+
+	for start := 0; start < len(ops); {
+		// Find the next change
+		for start < len(ops) && ops[start].Kind == ' ' {
+			start++
+		}
+		if start == len(ops) {
+			break
+		}
+
+		// Extend the hunk until more than 2*diffContext unchanged lines separate two changes
+		end := start
+		for i := start; i < len(ops); i++ {
+			if ops[i].Kind != ' ' {
+				end = i + 1
+			} else if i-end >= 2*diffContext {
+				break
+			}
+		}
+
+		hunkStart := max(start-diffContext, 0)
+		hunkEnd := min(end+diffContext, len(ops))
+
+		beforeLine, afterLine := 1, 1
+		for _, op := range ops[:hunkStart] {
+			if op.Kind != '+' {
+				beforeLine++
+			}
+			if op.Kind != '-' {
+				afterLine++
+			}
+		}
+
+		beforeCount, afterCount := 0, 0
+		for _, op := range ops[hunkStart:hunkEnd] {
+			if op.Kind != '+' {
+				beforeCount++
+			}
+			if op.Kind != '-' {
+				afterCount++
+			}
+		}
+
+		builder.WriteString(fmt.Sprintf("@@ -%d,%d +%d,%d @@\n", beforeLine, beforeCount, afterLine, afterCount))
+		for _, op := range ops[hunkStart:hunkEnd] {
+			builder.WriteString(string(op.Kind) + op.Line + "\n")
+		}
+
+		start = hunkEnd
+	}
+
+	return builder.String()
+}
+
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+// diffLines computes the longest common subsequence of both line lists and returns the edit script.
+func diffLines(before, after []string) []diffOp {
+	lcs := make([][]int, len(before)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i] == after[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []diffOp
+	i, j := 0, 0
+	for i < len(before) && j < len(after) {
+		if before[i] == after[j] {
+			ops = append(ops, diffOp{' ', before[i]})
+			i++
+			j++
+		} else if lcs[i+1][j] >= lcs[i][j+1] {
+			ops = append(ops, diffOp{'-', before[i]})
+			i++
+		} else {
+			ops = append(ops, diffOp{'+', after[j]})
+			j++
+		}
+	}
+	for ; i < len(before); i++ {
+		ops = append(ops, diffOp{'-', before[i]})
+	}
+	for ; j < len(after); j++ {
+		ops = append(ops, diffOp{'+', after[j]})
+	}
+	return ops
+}
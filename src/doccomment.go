@@ -0,0 +1,211 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DocParam is a parameter documented by a @param tag, with its type and default value for functions.
+type DocParam struct {
+	Name        string
+	Type        string
+	Default     string
+	Description string
+}
+
+// DocComment is a doc comment split into its text and its Doxygen / Javadoc tags, written '@tag' or '\tag'.
+// Unknown tags are left in the text.
+type DocComment struct {
+	// Text is the comment without its tags, one cleaned line per source line
+	Text    []string
+	Params  []DocParam
+	Returns string
+	// See are the references of @see and @sa, e.g. 'UFlowPilotTask' or 'UFlowPilotTask::Enter'
+	See      []string
+	Notes    []string
+	Warnings []string
+	// Deprecated is set by @deprecated, DeprecatedReason is the text following it
+	Deprecated       bool
+	DeprecatedReason string
+}
+
+// HasTags reports whether the comment has any tag beside its text.
+func (d DocComment) HasTags() bool {
+	return len(d.Params) > 0 || d.Returns != "" || len(d.See) > 0 || len(d.Notes) > 0 || len(d.Warnings) > 0 || d.Deprecated
+}
+
+// DeprecationNotice returns the reason given by @deprecated, or a default notice.
+func (d DocComment) DeprecationNotice() string {
+	if d.DeprecatedReason != "" {
+		return d.DeprecatedReason
+	}
+	return "This API is deprecated."
+}
+
+// Doc returns the documentation of the function split into its text and tags. When a parameter is documented, Params
+// lists every parameter of the signature with its @param description, if any. A @param naming no parameter is left
+// out, docWarnings reports it. UE_DEPRECATED counts as @deprecated.
+func (f *FunctionInfo) Doc() DocComment {
+	doc := parseDocComment(f.Documentation())
+	if len(doc.Params) > 0 {
+		var params []DocParam
+		for _, parameter := range f.Signature.Parameters {
+			param := DocParam{Name: parameter.Name, Type: parameter.Type, Default: parameter.Default}
+			for _, documented := range doc.Params {
+				if documented.Name == parameter.Name {
+					param.Description = documented.Description
+				}
+			}
+			params = append(params, param)
+		}
+		doc.Params = params
+	}
+	if f.Signature.IsDeprecated && !doc.Deprecated {
+		doc.Deprecated = true
+		doc.DeprecatedReason = f.Signature.DeprecationMessage
+	}
+	return doc
+}
+
+// Doc returns the documentation of the type split into its text and tags.
+func (d *DataInfo) Doc() DocComment {
+	return parseDocComment(d.Documentation())
+}
+
+// docTag returns the lower case name of the known tag starting line, and the text after it. The name is "" when line
+// does not start with a tag.
+func docTag(line string) (string, string) {
+	if line == "" || (line[0] != '@' && line[0] != '\\') {
+		return "", line
+	}
+	end := 1
+	for end < len(line) && (line[end] >= 'a' && line[end] <= 'z' || line[end] >= 'A' && line[end] <= 'Z') {
+		end++
+	}
+	tag := strings.ToLower(line[1:end])
+	switch tag {
+	case "param", "return", "returns", "result", "see", "sa", "note", "remark", "remarks", "warning", "deprecated", "brief", "short":
+		return tag, strings.TrimSpace(line[end:])
+	}
+	return "", line
+}
+
+// parseDocComment parses the tags of comments. The text of a tag runs until the next tag or an empty line.
+func parseDocComment(comments []string) DocComment {
+	var doc DocComment
+	// Text of the open tag, continuation lines are added to it
+	var current *string
+	for _, comment := range comments {
+		line := cleanComment(comment)
+		tag, rest := docTag(line)
+		switch tag {
+		case "":
+			if line == "" {
+				current = nil
+			}
+			if current != nil {
+				*current = joinDocText(*current, line)
+				continue
+			}
+			doc.Text = append(doc.Text, line)
+		case "brief", "short":
+			current = nil
+			doc.Text = append(doc.Text, rest)
+		case "param":
+			name, description := splitDocParam(rest)
+			doc.Params = append(doc.Params, DocParam{Name: name, Description: description})
+			current = &doc.Params[len(doc.Params)-1].Description
+		case "return", "returns", "result":
+			doc.Returns = joinDocText(doc.Returns, rest)
+			current = &doc.Returns
+		case "see", "sa":
+			current = nil
+			doc.See = append(doc.See, strings.FieldsFunc(rest, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' })...)
+		case "note", "remark", "remarks":
+			doc.Notes = append(doc.Notes, rest)
+			current = &doc.Notes[len(doc.Notes)-1]
+		case "warning":
+			doc.Warnings = append(doc.Warnings, rest)
+			current = &doc.Warnings[len(doc.Warnings)-1]
+		case "deprecated":
+			doc.Deprecated = true
+			doc.DeprecatedReason = joinDocText(doc.DeprecatedReason, rest)
+			current = &doc.DeprecatedReason
+		}
+	}
+
+	// Comments without tags keep every line, so they render as before
+	if doc.HasTags() {
+		for len(doc.Text) > 0 && doc.Text[len(doc.Text)-1] == "" {
+			doc.Text = doc.Text[:len(doc.Text)-1]
+		}
+	}
+	return doc
+}
+
+func joinDocText(text, line string) string {
+	if text == "" {
+		return line
+	}
+	if line == "" {
+		return text
+	}
+	return text + " " + line
+}
+
+// splitDocParam splits the text of a @param tag into the parameter name and its description. A direction such as
+// '[in]' and a separator such as '-' or ':' after the name are dropped.
+func splitDocParam(text string) (string, string) {
+	if strings.HasPrefix(text, "[") {
+		if end := strings.Index(text, "]"); end >= 0 {
+			text = strings.TrimSpace(text[end+1:])
+		}
+	}
+	name, description, _ := strings.Cut(text, " ")
+	name = strings.TrimRight(name, ":")
+	description = strings.TrimSpace(description)
+	description = strings.TrimSpace(strings.TrimLeft(description, "-:"))
+	return name, description
+}
+
+// docWarnings returns the mismatches between the doc comments of a file and its declarations: parameters documented
+// but not declared, parameters left out when others are documented, and parameters or a return value documented
+// on a type.
+func docWarnings(fileInfo *FileInfo) []string {
+	var warnings []string
+	for i := range fileInfo.Data {
+		d := &fileInfo.Data[i]
+		doc := d.Doc()
+		for _, param := range doc.Params {
+			warnings = append(warnings, fmt.Sprintf("%s:%d: @param %s documents type %s, which has no parameters", fileInfo.Path, d.Line, param.Name, d.ScopedName()))
+		}
+		if doc.Returns != "" {
+			warnings = append(warnings, fmt.Sprintf("%s:%d: @return documents type %s, which returns nothing", fileInfo.Path, d.Line, d.ScopedName()))
+		}
+
+		for j := range d.Functions {
+			function := &d.Functions[j]
+			doc := parseDocComment(function.Documentation())
+			if len(doc.Params) == 0 {
+				continue
+			}
+			declared := map[string]bool{}
+			for _, parameter := range function.Signature.Parameters {
+				declared[parameter.Name] = true
+			}
+			documented := map[string]bool{}
+			for _, param := range doc.Params {
+				documented[param.Name] = true
+				if !declared[param.Name] {
+					warnings = append(warnings, fmt.Sprintf("%s:%d: @param %s does not match any parameter of %s::%s", fileInfo.Path, function.Line, param.Name, d.ScopedName(), function.Name))
+				}
+			}
+			for _, parameter := range function.Signature.Parameters {
+				if parameter.Name != "" && !documented[parameter.Name] {
+					warnings = append(warnings, fmt.Sprintf("%s:%d: parameter %s of %s::%s is not documented", fileInfo.Path, function.Line, parameter.Name, d.ScopedName(), function.Name))
+				}
+			}
+		}
+	}
+	return warnings
+}
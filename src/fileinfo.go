@@ -1,39 +1,19 @@
 package main
 
 import (
-	"bufio"
-	"fmt"
-	"strings"
-)
-
-type LineId int
-
-const (
-	Empty = iota
-	Comment
-	Class
-	Struct
-	Enum
-	EnumProp
-	Function
-	Property
-	AccessModifier
-	OpenIgnore
-	CloseIgnore
-	OpenBracket
-	CloseBracket
+	"slices"
 )
 
 type FileInfo struct {
-	Path string
-	Name string
-	Data []DataInfo
+	Path string `json:"path"`
+	// Path relative to the source folder, with forward slashes
+	RelPath string     `json:"relPath"`
+	Name    string     `json:"name"`
+	Data    []DataInfo `json:"types"`
 }
 
-func (f *FileInfo) OutputInfo(writer *bufio.Writer) (enums, structs, classes []DataInfo) {
-
-	writer.WriteString("\n__FileName:__ `" + f.Name + "`\n")
-
+// SplitTypes returns the enums, structs and classes of the file, each in declaration order.
+func (f *FileInfo) SplitTypes() (enums, structs, classes []DataInfo) {
 	for _, data := range f.Data {
 		if data.IsEnum {
 			enums = append(enums, data)
@@ -43,55 +23,14 @@ func (f *FileInfo) OutputInfo(writer *bufio.Writer) (enums, structs, classes []D
 			classes = append(classes, data)
 		}
 	}
+	return
+}
 
-	if len(enums) > 0 {
-		writer.WriteString("- __Enum List:__ \n")
-
-		writer.WriteString("[ ")
-		for i, e := range enums {
-			isLast := i == len(enums)-1
-			if !isLast {
-				writer.WriteString(fmt.Sprintf("[`" + e.Name + "`](#" + strings.ToLower(e.Name) + ") | "))
-			} else {
-				writer.WriteString(fmt.Sprintf("[`" + e.Name + "`](#" + strings.ToLower(e.Name) + ")"))
-			}
-		}
-		writer.WriteString(" ]\n")
-	}
-
-	if len(structs) > 0 {
-		writer.WriteString("- __Struct List:__ \n")
-
-		writer.WriteString("[ ")
-		for i, s := range structs {
-			if s.HasDocumentation() {
-				isLast := i == len(structs)-1
-				if !isLast {
-					writer.WriteString(fmt.Sprintf("[`" + s.Name + "`](#" + strings.ToLower(s.Name) + ") | "))
-				} else {
-					writer.WriteString(fmt.Sprintf("[`" + s.Name + "`](#" + strings.ToLower(s.Name) + ")"))
-				}
-			}
-		}
-		writer.WriteString(" ]\n")
-	}
-
-	if len(classes) > 0 {
-		writer.WriteString("- __Class List:__ \n")
-
-		writer.WriteString("[ ")
-		for i, c := range classes {
-			if c.HasDocumentation() {
-				isLast := i == len(classes)-1
-				if !isLast {
-					writer.WriteString(fmt.Sprintf("[`" + c.Name + "`](#" + strings.ToLower(c.Name) + ") | "))
-				} else {
-					writer.WriteString(fmt.Sprintf("[`" + c.Name + "`](#" + strings.ToLower(c.Name) + ")"))
-				}
-			}
-		}
-		writer.WriteString(" ]\n")
+// FilterAccess drops the properties and functions less visible than maxAccess, e.g. the private and protected ones for Public.
+func (f *FileInfo) FilterAccess(maxAccess AccessType) {
+	for i := range f.Data {
+		data := &f.Data[i]
+		data.Properties = slices.DeleteFunc(data.Properties, func(prop PropertyInfo) bool { return prop.Access > maxAccess })
+		data.Functions = slices.DeleteFunc(data.Functions, func(function FunctionInfo) bool { return function.Access > maxAccess })
 	}
-
-	return
 }
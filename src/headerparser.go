@@ -1 +1,1085 @@
 package main
+
+import (
+	"strings"
+)
+
+type TokenKind int
+
+const (
+	TokenEOF TokenKind = iota
+	TokenIdent
+	TokenNumber
+	TokenString
+	TokenPunct
+	TokenComment
+	TokenDirective
+)
+
+type Token struct {
+	Kind TokenKind
+	Text string
+	// Line of the first character, starting at 1
+	Line int
+	// Byte offsets of the token in the source
+	Start int
+	End   int
+}
+
+// Multi character punctuators, longest first. '>>' is left out on purpose so nested template arguments close one by one.
+var punctuators = []string{"...", "<=>", "->*", "::", "->", "==", "!=", "<=", ">=", "&&", "||", "++", "--", "+=", "-=", "*=", "/=", "%=", "&=", "|=", "^=", "<<", ".*", "##"}
+
+// Prefixes that turn a following quote into a string or character literal.
+var literalPrefixes = []string{"L", "u", "U", "u8", "R", "LR", "uR", "UR", "u8R"}
+
+// Macros UE places in front of a declaration, they are kept as part of it.
+var declarationMacros = []string{"FORCEINLINE", "FORCENOINLINE", "FORCEINLINE_DEBUGGABLE", "UE_DEPRECATED", "UE_NODISCARD", "UE_DEPRECATED_FORGAME", "CONSTEXPR"}
+
+// Reflection macros that describe the declaration that follows them.
+var typeMacros = []string{"UCLASS", "USTRUCT", "UENUM", "UINTERFACE", "UDELEGATE"}
+var memberMacros = []string{"UPROPERTY", "UFUNCTION"}
+
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isIdentChar(c byte) bool {
+	return isIdentStart(c) || isDigit(c)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// tokenize splits a header into tokens. Whitespace is dropped, comments and preprocessor lines are kept as single tokens.
+// It never fails: unterminated comments and literals simply run to the end of the source.
+func tokenize(source string) []Token {
+	var tokens []Token
+
+	line := 1
+	atLineStart := true
+	for i := 0; i < len(source); {
+		c := source[i]
+		if c == '\n' {
+			line++
+			atLineStart = true
+			i++
+			continue
+		}
+		if c == ' ' || c == '\t' || c == '\r' || c == '\f' || c == '\v' {
+			i++
+			continue
+		}
+
+		start := i
+		startLine := line
+		kind := TokenPunct
+
+		switch {
+		case c == '#' && atLineStart:
+			kind = TokenDirective
+			for i < len(source) && source[i] != '\n' {
+				if source[i] == '\\' && strings.HasPrefix(strings.TrimLeft(source[i+1:], " \t\r"), "\n") {
+					i = i + 1 + strings.Index(source[i+1:], "\n") + 1
+					line++
+					continue
+				}
+				i++
+			}
+		case strings.HasPrefix(source[i:], "//"):
+			kind = TokenComment
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(source[i:], "/*"):
+			kind = TokenComment
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				i = len(source)
+			} else {
+				i += 2 + end + 2
+			}
+			line += strings.Count(source[start:i], "\n")
+		case c == '"' || c == '\'':
+			kind = TokenString
+			i = skipQuoted(source, i)
+		case isIdentStart(c):
+			kind = TokenIdent
+			for i < len(source) && isIdentChar(source[i]) {
+				i++
+			}
+			if i < len(source) && (source[i] == '"' || source[i] == '\'') && containsString(literalPrefixes, source[start:i]) {
+				kind = TokenString
+				if strings.HasSuffix(source[start:i], "R") && source[i] == '"' {
+					i = skipRawString(source, i)
+				} else {
+					i = skipQuoted(source, i)
+				}
+				line += strings.Count(source[start:i], "\n")
+			}
+		case isDigit(c) || (c == '.' && i+1 < len(source) && isDigit(source[i+1])):
+			kind = TokenNumber
+			for i < len(source) {
+				n := source[i]
+				isExponentSign := (n == '+' || n == '-') && strings.ContainsRune("eEpP", rune(source[i-1]))
+				if !isIdentChar(n) && n != '.' && n != '\'' && !isExponentSign {
+					break
+				}
+				i++
+			}
+		default:
+			i += punctuatorLength(source[i:])
+		}
+
+		atLineStart = false
+		tokens = append(tokens, Token{kind, strings.TrimSpace(source[start:i]), startLine, start, i})
+	}
+
+	return append(tokens, Token{Kind: TokenEOF, Line: line, Start: len(source), End: len(source)})
+}
+
+func punctuatorLength(source string) int {
+	for _, punct := range punctuators {
+		if strings.HasPrefix(source, punct) {
+			return len(punct)
+		}
+	}
+	return 1
+}
+
+// skipQuoted returns the offset after the string or character literal opening at start.
+func skipQuoted(source string, start int) int {
+	quote := source[start]
+	for i := start + 1; i < len(source); i++ {
+		switch source[i] {
+		case '\\':
+			i++
+		case quote:
+			return i + 1
+		case '\n':
+			return i
+		}
+	}
+	return len(source)
+}
+
+// skipRawString returns the offset after the raw string R"delim( ... )delim" opening at start.
+func skipRawString(source string, start int) int {
+	open := strings.IndexByte(source[start:], '(')
+	if open < 0 {
+		return skipQuoted(source, start)
+	}
+	closing := ")" + source[start+1:start+open] + "\""
+	end := strings.Index(source[start+open:], closing)
+	if end < 0 {
+		return len(source)
+	}
+	return start + open + end + len(closing)
+}
+
+// directiveName returns the keyword of a preprocessor line, e.g. "if" for "#  if WITH_EDITOR".
+func directiveName(directive string) string {
+	fields := strings.Fields(strings.TrimPrefix(directive, "#"))
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
+
+// filterConditionals drops everything inside #if / #ifdef / #ifndef blocks, editor and debug only code is not documented.
+// The conditional directives themselves are dropped too. Include guards are recognized and kept open.
+func filterConditionals(tokens []Token) []Token {
+	var filtered []Token
+	var skipStack []bool
+
+	isSkipping := func() bool {
+		for _, skip := range skipStack {
+			if skip {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, tok := range tokens {
+		if tok.Kind != TokenDirective {
+			if !isSkipping() || tok.Kind == TokenEOF {
+				filtered = append(filtered, tok)
+			}
+			continue
+		}
+
+		switch directiveName(tok.Text) {
+		case "if", "ifdef", "ifndef":
+			skipStack = append(skipStack, !isIncludeGuard(tokens, i))
+		case "else", "elif", "elifdef", "elifndef":
+		case "endif":
+			if len(skipStack) > 0 {
+				skipStack = skipStack[:len(skipStack)-1]
+			}
+		default:
+			if !isSkipping() {
+				filtered = append(filtered, tok)
+			}
+		}
+	}
+	return filtered
+}
+
+// isIncludeGuard reports whether tokens[i] is the '#ifndef X' of a '#ifndef X / #define X' pair.
+func isIncludeGuard(tokens []Token, i int) bool {
+	fields := strings.Fields(strings.TrimPrefix(tokens[i].Text, "#"))
+	if len(fields) != 2 || fields[0] != "ifndef" || i+1 >= len(tokens) || tokens[i+1].Kind != TokenDirective {
+		return false
+	}
+	next := strings.Fields(strings.TrimPrefix(tokens[i+1].Text, "#"))
+	return len(next) >= 2 && next[0] == "define" && next[1] == fields[1]
+}
+
+// normalizeText joins the lines of a declaration spanning several lines into one.
+func normalizeText(text string) string {
+	var parts []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			parts = append(parts, line)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// commentLines splits a comment token into the lines stored in Comments.
+// Block comment lines with nothing but delimiters are dropped at the start and the end.
+func commentLines(comment string) []string {
+	if strings.HasPrefix(comment, "//") {
+		return []string{comment}
+	}
+
+	var lines []string
+	for _, line := range strings.Split(comment, "\n") {
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	for len(lines) > 0 && cleanComment(lines[0]) == "" {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && cleanComment(lines[len(lines)-1]) == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func isAllCapsIdentifier(text string) bool {
+	hasLetter := false
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c >= 'a' && c <= 'z' {
+			return false
+		}
+		if c >= 'A' && c <= 'Z' {
+			hasLetter = true
+		}
+	}
+	return hasLetter && len(text) > 1
+}
+
+func isApiMacro(text string) bool {
+	return strings.HasSuffix(text, "_API")
+}
+
+// isStatementMacro reports whether tok may be a macro standing for a whole statement rather than starting a declaration.
+// The delegate macros have mixed case suffixes, e.g. DECLARE_DYNAMIC_MULTICAST_DELEGATE_OneParam.
+func isStatementMacro(tok Token) bool {
+	if tok.Kind != TokenIdent || isApiMacro(tok.Text) || containsString(declarationMacros, tok.Text) {
+		return false
+	}
+	return isAllCapsIdentifier(tok.Text) || strings.HasPrefix(tok.Text, "DECLARE_")
+}
+
+type headerParser struct {
+	source   string
+	tokens   []Token
+	pos      int
+	fileInfo *FileInfo
+
+	// Doc comment lines waiting for the next declaration
+	comments []string
+	// UCLASS/USTRUCT/UENUM waiting for the next type, UPROPERTY/UFUNCTION for the next member
+	typeMacro   string
+	memberMacro string
+
+	// namespace encloses the current declarations, e.g. 'FlowPilot::Detail'
+	namespace string
+
+	// A comment starting on trailingLine is appended to the declaration that just ended there
+	trailingLine   int
+	attachTrailing func(comment string)
+}
+
+// parseHeader tokenizes source and appends every class, struct and enum it declares to fileInfo.Data.
+func parseHeader(source string, fileInfo *FileInfo) {
+	p := &headerParser{
+		source:   source,
+		tokens:   filterConditionals(tokenize(source)),
+		fileInfo: fileInfo,
+	}
+
+	for p.peek().Kind != TokenEOF {
+		p.parseBlock(-1, Public)
+		// Stray closing brace at file scope
+		if p.peek().Text == "}" {
+			p.next()
+		}
+	}
+}
+
+func (p *headerParser) peek() Token {
+	return p.peekAt(0)
+}
+
+func (p *headerParser) peekAt(offset int) Token {
+	if p.pos+offset < len(p.tokens) {
+		return p.tokens[p.pos+offset]
+	}
+	return p.tokens[len(p.tokens)-1]
+}
+
+func (p *headerParser) next() Token {
+	tok := p.peek()
+	if p.pos < len(p.tokens)-1 {
+		p.pos++
+	}
+	return tok
+}
+
+// text returns the source covering tokens[from] to tokens[to], both included, on one line. Line comments are left out,
+// so a '// ...' inside a wrapped declaration does not swallow the rest of it, and a line break becomes a space, or nothing
+// next to a bracket or a separator: 'Foo(\n\tint32 A,\n\tint32 B\n)' becomes 'Foo(int32 A, int32 B)'.
+func (p *headerParser) text(from, to int) string {
+	if from > to || to >= len(p.tokens) {
+		return ""
+	}
+
+	var builder strings.Builder
+	prev := -1
+	for i := from; i <= to; i++ {
+		tok := p.tokens[i]
+		if tok.Kind == TokenComment && strings.HasPrefix(tok.Text, "//") {
+			continue
+		}
+		if prev >= 0 {
+			gap := p.source[p.tokens[prev].End:tok.Start]
+			if i == prev+1 && !strings.Contains(gap, "\n") {
+				builder.WriteString(gap)
+			} else if !containsString([]string{"(", "[", "<"}, p.tokens[prev].Text) && !containsString([]string{")", "]", ">", ",", ";"}, tok.Text) {
+				builder.WriteString(" ")
+			}
+		}
+		builder.WriteString(normalizeText(p.source[tok.Start:tok.End]))
+		prev = i
+	}
+	return builder.String()
+}
+
+func (p *headerParser) addComment(tok Token) {
+	if strings.Contains(tok.Text, "Copyright") {
+		return
+	}
+
+	// Trailing when it shares the line of the previous declaration and no other declaration follows on that line
+	nextTok := p.peek()
+	isTrailing := nextTok.Line > tok.Line || nextTok.Text == "}" || nextTok.Kind == TokenEOF
+	if p.attachTrailing != nil && tok.Line == p.trailingLine && isTrailing {
+		for _, line := range commentLines(tok.Text) {
+			p.attachTrailing(line)
+		}
+		return
+	}
+	p.comments = append(p.comments, commentLines(tok.Text)...)
+}
+
+func (p *headerParser) takeComments() []string {
+	comments := p.comments
+	p.comments = nil
+	return comments
+}
+
+func (p *headerParser) setTrailing(line int, attach func(comment string)) {
+	p.trailingLine = line
+	p.attachTrailing = attach
+}
+
+// skipBalanced skips from an opening token to its matching closing token, both included.
+func (p *headerParser) skipBalanced(open, close string) {
+	depth := 0
+	for p.peek().Kind != TokenEOF {
+		tok := p.next()
+		if tok.Kind != TokenPunct {
+			continue
+		}
+		if tok.Text == open {
+			depth++
+		} else if tok.Text == close {
+			depth--
+			if depth <= 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipStatement skips to the ';' ending the current statement, or past the body of a braced one.
+func (p *headerParser) skipStatement() {
+	for p.peek().Kind != TokenEOF {
+		switch p.peek().Text {
+		case ";":
+			p.next()
+			return
+		case "}":
+			return
+		case "{":
+			p.skipBalanced("{", "}")
+			if p.peek().Text == ";" {
+				p.next()
+			}
+			return
+		case "(":
+			p.skipBalanced("(", ")")
+		default:
+			p.next()
+		}
+	}
+}
+
+// macroCall consumes a macro name and its parenthesized arguments and returns the normalized text.
+func (p *headerParser) macroCall() string {
+	start := p.pos
+	p.next()
+	if p.peek().Text == "(" {
+		p.skipBalanced("(", ")")
+	}
+	return p.text(start, p.pos-1)
+}
+
+// skipDeclarators skips what follows the closing brace of a type, e.g. the 'Instance' in '} Instance;', and the ';'.
+func (p *headerParser) skipDeclarators() {
+	for {
+		tok := p.peek()
+		if tok.Kind == TokenIdent || tok.Kind == TokenNumber || strings.Contains("*&,[]", tok.Text) && tok.Kind == TokenPunct {
+			p.next()
+			continue
+		}
+		break
+	}
+	if p.peek().Text == ";" {
+		p.next()
+	}
+}
+
+// parseBlock parses declarations until the '}' closing the current scope or the end of the file.
+// The '}' is left for the caller. owner is the index in fileInfo.Data of the type whose body this is, or -1.
+func (p *headerParser) parseBlock(owner int, access AccessType) {
+	for {
+		tok := p.peek()
+		if tok.Kind == TokenEOF {
+			return
+		}
+
+		if tok.Kind == TokenComment {
+			p.next()
+			p.addComment(tok)
+			continue
+		}
+		p.attachTrailing = nil
+
+		switch {
+		case tok.Kind == TokenDirective:
+			p.next()
+			p.comments = nil
+		case tok.Text == "}":
+			return
+		case tok.Text == ";":
+			p.next()
+		case (tok.Text == "public" || tok.Text == "protected" || tok.Text == "private") && p.peekAt(1).Text == ":":
+			access = accessTypeFromString(tok.Text)
+			p.next()
+			p.next()
+		case tok.Text == "namespace":
+			p.parseNamespace()
+		case tok.Text == "extern" && p.peekAt(1).Kind == TokenString && p.peekAt(2).Text == "{":
+			p.pos += 3
+			p.parseBlock(owner, access)
+			if p.peek().Text == "}" {
+				p.next()
+			}
+		case tok.Text == "template":
+			p.next()
+			if p.peek().Text == "<" {
+				p.skipBalanced("<", ">")
+			}
+		case containsString(typeMacros, tok.Text):
+			p.typeMacro = p.macroCall()
+		case containsString(memberMacros, tok.Text):
+			p.memberMacro = p.macroCall()
+		case tok.Text == "class" || tok.Text == "struct" || tok.Text == "union":
+			if !p.parseType(owner, access) {
+				p.parseMember(owner, access)
+			}
+		case tok.Text == "enum":
+			if !p.parseEnum(owner, access) {
+				p.parseMember(owner, access)
+			}
+		case tok.Text == "friend" || tok.Text == "static_assert":
+			p.skipStatement()
+			p.comments = nil
+		case isStatementMacro(tok) && p.peekAt(1).Text == "(":
+			// Statement macros such as GENERATED_BODY() or DECLARE_DELEGATE(...)
+			p.macroCall()
+			if p.peek().Text == ";" {
+				p.next()
+			}
+			p.comments = nil
+		case isStatementMacro(tok) && p.peekAt(1).Line > tok.Line:
+			// Statement macros without arguments such as PRAGMA_DISABLE_DEPRECATION_WARNINGS
+			p.next()
+		default:
+			p.parseMember(owner, access)
+		}
+	}
+}
+
+// parseNamespace parses a namespace, possibly nested as in 'namespace A::B', whose types are recorded with their
+// Namespace. Anonymous namespaces add nothing to it.
+func (p *headerParser) parseNamespace() {
+	p.next()
+	start := p.pos
+	for p.peek().Kind == TokenIdent || p.peek().Text == "::" {
+		p.next()
+	}
+	name := p.text(start, p.pos-1)
+
+	if p.peek().Text != "{" {
+		// Namespace alias
+		p.skipStatement()
+		return
+	}
+
+	p.next()
+	p.comments = nil
+	outer := p.namespace
+	p.namespace = joinScope(outer, name)
+	p.parseBlock(-1, Public)
+	p.namespace = outer
+	p.comments = nil
+	if p.peek().Text == "}" {
+		p.next()
+	}
+}
+
+// findBodyStart looks ahead for the '{' opening a definition. It returns -1 when a ';' ends the statement first.
+func (p *headerParser) findBodyStart() int {
+	depth := 0
+	for i := p.pos; i < len(p.tokens); i++ {
+		tok := p.tokens[i]
+		if tok.Kind != TokenPunct {
+			if tok.Kind == TokenEOF {
+				return -1
+			}
+			continue
+		}
+		switch tok.Text {
+		case "(":
+			// A call like 'GetOwner()' makes this a function returning an elaborated type, alignas(...) does not
+			if depth == 0 && i > p.pos && p.tokens[i-1].Kind == TokenIdent && !isAllCapsIdentifier(p.tokens[i-1].Text) && p.tokens[i-1].Text != "alignas" {
+				return -1
+			}
+			depth++
+		case "[":
+			depth++
+		case ")", "]":
+			depth--
+		case ";", "}":
+			if depth <= 0 {
+				return -1
+			}
+		case "{":
+			if depth <= 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// isForwardDeclaration reports whether tokens[from:to] is only a keyword, an optional API macro and a name.
+func (p *headerParser) isForwardDeclaration(from, to int) bool {
+	names := 0
+	for i := from; i < to; i++ {
+		tok := p.tokens[i]
+		switch {
+		case tok.Kind == TokenComment:
+		case tok.Kind == TokenIdent && (tok.Text == "class" || tok.Text == "struct" || tok.Text == "union" || tok.Text == "enum" || isApiMacro(tok.Text)):
+		case tok.Kind == TokenIdent:
+			names++
+		case tok.Text == "::":
+		case tok.Text == ":":
+			// Opaque enum declaration with an underlying type
+			return names == 1
+		default:
+			return false
+		}
+	}
+	return names == 1
+}
+
+// statementEnd returns the index of the ';' ending the statement starting at the current token.
+func (p *headerParser) statementEnd() int {
+	depth := 0
+	for i := p.pos; i < len(p.tokens); i++ {
+		switch p.tokens[i].Text {
+		case "(", "[", "{":
+			depth++
+		case ")", "]", "}":
+			depth--
+		case ";":
+			if depth <= 0 {
+				return i
+			}
+		}
+		if p.tokens[i].Kind == TokenEOF {
+			return i
+		}
+	}
+	return len(p.tokens) - 1
+}
+
+// parseTypeHead extracts the type name and its parents from the tokens between 'class' and '{'.
+func (p *headerParser) parseTypeHead(from, to int) (name string, parents []string) {
+	parentStart := -1
+	for i := from; i < to; i++ {
+		tok := p.tokens[i]
+		if tok.Text == ":" && tok.Kind == TokenPunct {
+			parentStart = i + 1
+			break
+		}
+		if tok.Text == "(" || tok.Text == "[" {
+			// alignas(...), [[attributes]]
+			depth := 0
+			for ; i < to; i++ {
+				if p.tokens[i].Text == "(" || p.tokens[i].Text == "[" {
+					depth++
+				} else if p.tokens[i].Text == ")" || p.tokens[i].Text == "]" {
+					depth--
+					if depth == 0 {
+						break
+					}
+				}
+			}
+			continue
+		}
+		if tok.Kind == TokenIdent && !isApiMacro(tok.Text) && tok.Text != "final" && tok.Text != "alignas" && tok.Text != "class" && tok.Text != "struct" && tok.Text != "union" {
+			name = tok.Text
+		}
+	}
+
+	if parentStart < 0 {
+		return
+	}
+
+	angleDepth := 0
+	partStart := parentStart
+	for i := parentStart; i <= to; i++ {
+		tok := p.tokens[i]
+		switch {
+		case tok.Text == "<":
+			angleDepth++
+		case tok.Text == ">":
+			angleDepth--
+		case i == to || (tok.Text == "," && angleDepth == 0):
+			for partStart < i && (p.tokens[partStart].Text == "public" || p.tokens[partStart].Text == "protected" || p.tokens[partStart].Text == "private" || p.tokens[partStart].Text == "virtual") {
+				partStart++
+			}
+			if parent := p.text(partStart, i-1); parent != "" {
+				parents = append(parents, parent)
+			}
+			partStart = i + 1
+		}
+	}
+	return
+}
+
+// parseType parses a class, struct or union definition. It returns false, consuming nothing, when the statement
+// is not a definition but a member using an elaborated type, e.g. 'class UObject* Owner;'.
+func (p *headerParser) parseType(owner int, access AccessType) bool {
+	keyword := p.peek().Text
+	line := p.peek().Line
+	bodyStart := p.findBodyStart()
+	if bodyStart < 0 {
+		end := p.statementEnd()
+		if !p.isForwardDeclaration(p.pos, end) {
+			return false
+		}
+		p.pos = end
+		p.next()
+		p.comments = nil
+		p.typeMacro = ""
+		return true
+	}
+
+	name, parents := p.parseTypeHead(p.pos+1, bodyStart)
+	p.pos = bodyStart
+	p.next()
+
+	defaultAccess := AccessType(Private)
+	if keyword != "class" {
+		defaultAccess = Public
+	}
+
+	if name == "" {
+		// Anonymous struct or union, its members belong to the enclosing type
+		p.comments = nil
+		p.parseBlock(owner, access)
+	} else {
+		p.fileInfo.Data = append(p.fileInfo.Data, DataInfo{
+			Name:       name,
+			Namespace:  p.namespace,
+			Outer:      p.outer(owner),
+			Macro:      p.typeMacro,
+			Specifiers: parseSpecifiers(p.typeMacro),
+			Parents:    parents,
+			Comments:   p.takeComments(),
+			IsStruct:   keyword != "class",
+			IsEnum:     false,
+			Line:       line,
+		})
+		p.typeMacro = ""
+		p.parseBlock(len(p.fileInfo.Data)-1, defaultAccess)
+	}
+
+	p.comments = nil
+	if p.peek().Text == "}" {
+		p.next()
+		p.skipDeclarators()
+	}
+	return true
+}
+
+// outer returns the scoped name of the type owner, which encloses the type being parsed, or "" at namespace scope.
+func (p *headerParser) outer(owner int) string {
+	if owner < 0 {
+		return ""
+	}
+	return p.fileInfo.Data[owner].ScopedName()
+}
+
+// parseEnum parses an enum definition and its values. Like parseType it returns false for members using 'enum' as elaborated type.
+func (p *headerParser) parseEnum(owner int, access AccessType) bool {
+	line := p.peek().Line
+	bodyStart := p.findBodyStart()
+	if bodyStart < 0 {
+		end := p.statementEnd()
+		if !p.isForwardDeclaration(p.pos, end) {
+			return false
+		}
+		p.pos = end
+		p.next()
+		p.comments = nil
+		p.typeMacro = ""
+		return true
+	}
+
+	name := ""
+	for i := p.pos + 1; i < bodyStart && p.tokens[i].Text != ":"; i++ {
+		tok := p.tokens[i]
+		if tok.Kind == TokenIdent && tok.Text != "class" && tok.Text != "struct" && !isApiMacro(tok.Text) {
+			name = tok.Text
+		}
+	}
+	p.pos = bodyStart
+	p.next()
+
+	info := DataInfo{
+		Name:       name,
+		Namespace:  p.namespace,
+		Outer:      p.outer(owner),
+		Macro:      p.typeMacro,
+		Specifiers: parseSpecifiers(p.typeMacro),
+		Comments:   p.takeComments(),
+		IsStruct:   false,
+		IsEnum:     true,
+		Line:       line,
+	}
+	p.typeMacro = ""
+
+	for {
+		tok := p.peek()
+		if tok.Kind == TokenEOF || tok.Text == "}" {
+			break
+		}
+		if tok.Kind == TokenComment {
+			p.next()
+			p.addComment(tok)
+			continue
+		}
+		p.attachTrailing = nil
+		if tok.Kind == TokenDirective || tok.Text == "," {
+			p.next()
+			continue
+		}
+
+		start := p.pos
+		for p.peek().Kind != TokenEOF && p.peek().Text != "," && p.peek().Text != "}" {
+			if p.peek().Text == "(" {
+				p.skipBalanced("(", ")")
+				continue
+			}
+			p.next()
+		}
+		if p.peek().Text == "," {
+			p.next()
+		}
+
+		// A trailing comment is a comment token starting after the value, keep it out of the declaration
+		end := p.pos - 1
+		for end > start && p.tokens[end].Kind == TokenComment {
+			end--
+		}
+
+		// UMETA(...) is kept apart, like the UPROPERTY of a field
+		macro := ""
+		declaration := p.text(start, end)
+		for i := start + 1; i <= end; i++ {
+			if p.tokens[i].Text == "UMETA" {
+				next := p.pos
+				p.pos = i
+				macro = p.macroCall()
+				declaration = p.text(start, i-1) + p.text(p.pos, end)
+				p.pos = next
+				break
+			}
+		}
+
+		// Every argument of UMETA is metadata, as ToolTip or DisplayName
+		specifiers := parseSpecifiers(macro)
+		specifiers.Meta = append(specifiers.List, specifiers.Meta...)
+		specifiers.List = nil
+
+		info.Properties = append(info.Properties, PropertyInfo{
+			Macro:       macro,
+			Specifiers:  specifiers,
+			Declaration: declaration,
+			Comments:    p.takeComments(),
+			Access:      Public,
+			Line:        p.tokens[start].Line,
+		})
+
+		index := len(info.Properties) - 1
+		p.setTrailing(p.tokens[end].Line, func(comment string) {
+			info.Properties[index].Comments = append(info.Properties[index].Comments, comment)
+		})
+
+		// Comments after the last value, before the '}', were read as part of it
+		for _, tok := range p.tokens[end+1 : p.pos] {
+			if tok.Kind == TokenComment {
+				p.addComment(tok)
+			}
+		}
+	}
+	p.attachTrailing = nil
+
+	if name != "" {
+		p.fileInfo.Data = append(p.fileInfo.Data, info)
+	}
+
+	p.comments = nil
+	if p.peek().Text == "}" {
+		p.next()
+		p.skipDeclarators()
+	}
+	return true
+}
+
+// opensDeclarator reports whether the '(' at tokens[i] groups a pointer declarator, as in 'void (*Callback)(int);' or
+// 'void (UFoo::*Method)();', rather than opening a parameter list.
+func opensDeclarator(tokens []Token, i int) bool {
+	next := i + 1
+	for next+1 < len(tokens) && tokens[next].Kind == TokenIdent && tokens[next+1].Text == "::" {
+		next += 2
+	}
+	return next < len(tokens) && (tokens[next].Text == "*" || tokens[next].Text == "&" || tokens[next].Text == "&&")
+}
+
+// declaratorHasParameters reports whether the parenthesised declarator opened at tokens[i] holds the parameter list
+// of a function, as in 'void (*GetHandler(int Id))(float);', the declaration of a function returning a function pointer.
+func declaratorHasParameters(tokens []Token, i int) bool {
+	depth := 0
+	for j := i; j < len(tokens); j++ {
+		switch tokens[j].Text {
+		case "(":
+			if depth > 0 && tokens[j-1].Kind == TokenIdent {
+				return true
+			}
+			depth++
+		case ")":
+			depth--
+			if depth == 0 {
+				return false
+			}
+		case ";", "{", "}":
+			return false
+		}
+	}
+	return false
+}
+
+// parseMember parses a function or a field declaration up to its ';', or past the body of an inline function.
+func (p *headerParser) parseMember(owner int, access AccessType) {
+	start := p.pos
+	end := -1
+	depth := 0
+	angleDepth := 0
+	isFunction := false
+	// A pointer to function field, e.g. 'void (*Callback)(int);'
+	isPointerField := false
+	hasAssign := false
+	initListIndex := -1
+	hasBody := false
+	isAlias := p.peek().Text == "typedef" || p.peek().Text == "using"
+
+loop:
+	for {
+		tok := p.peek()
+		if tok.Kind == TokenEOF {
+			break
+		}
+
+		prev := Token{}
+		if p.pos > start {
+			prev = p.tokens[p.pos-1]
+		}
+
+		if tok.Kind == TokenPunct && depth == 0 {
+			switch tok.Text {
+			case ";":
+				end = p.pos
+				p.next()
+				break loop
+			case "}":
+				// Missing ';', leave the brace to the enclosing scope
+				break loop
+			case "=":
+				// 'operator=' names a function, it assigns nothing
+				if prev.Text != "operator" {
+					hasAssign = true
+				}
+			case "<":
+				if !hasAssign && prev.Kind == TokenIdent && prev.Text != "operator" {
+					angleDepth++
+				}
+			case ">":
+				if angleDepth > 0 {
+					angleDepth--
+				}
+			case ":":
+				if isFunction && !hasAssign && initListIndex < 0 {
+					initListIndex = p.pos
+				}
+			case "(":
+				if opensDeclarator(p.tokens, p.pos) && !declaratorHasParameters(p.tokens, p.pos) {
+					isPointerField = !isFunction
+				} else if !hasAssign && !isFunction && !isPointerField && !isAlias && angleDepth == 0 && !containsString(declarationMacros, prev.Text) {
+					isFunction = true
+				}
+			case "{":
+				isInitializer := !isFunction || hasAssign || (initListIndex >= 0 && (prev.Kind == TokenIdent || prev.Text == ">"))
+				if !isInitializer {
+					end = p.pos - 1
+					hasBody = true
+					p.skipBalanced("{", "}")
+					if p.peek().Text == ";" {
+						p.next()
+					}
+					break loop
+				}
+				p.skipBalanced("{", "}")
+				continue
+			}
+		}
+
+		switch tok.Text {
+		case "(", "[":
+			depth++
+		case ")", "]":
+			depth--
+		}
+		p.next()
+	}
+
+	if end < 0 {
+		end = p.pos - 1
+	}
+	// The constructor initializer list is part of the definition, not of the signature
+	if hasBody && initListIndex >= 0 {
+		end = initListIndex - 1
+	}
+	for end > start && p.tokens[end].Kind == TokenComment {
+		end--
+	}
+
+	comments := p.takeComments()
+	macro := p.memberMacro
+	p.memberMacro = ""
+
+	if owner < 0 || end < start {
+		return
+	}
+
+	declaration := p.text(start, end)
+	if hasBody && !strings.HasSuffix(declaration, ";") {
+		declaration += ";"
+	}
+
+	data := &p.fileInfo.Data[owner]
+	if isFunction {
+		signature := parseSignature(declaration)
+		data.Functions = append(data.Functions, FunctionInfo{
+			Name:        signature.Name,
+			Macro:       macro,
+			Specifiers:  parseSpecifiers(macro),
+			Declaration: declaration,
+			Signature:   signature,
+			Comments:    comments,
+			Access:      access,
+			Line:        p.tokens[start].Line,
+		})
+
+		index := len(data.Functions) - 1
+		p.setTrailing(p.tokens[end].Line, func(comment string) {
+			p.fileInfo.Data[owner].Functions[index].Comments = append(p.fileInfo.Data[owner].Functions[index].Comments, comment)
+		})
+		return
+	}
+
+	data.Properties = append(data.Properties, PropertyInfo{
+		Macro:       macro,
+		Specifiers:  parseSpecifiers(macro),
+		Declaration: declaration,
+		Comments:    comments,
+		Access:      access,
+		Line:        p.tokens[start].Line,
+	})
+
+	index := len(data.Properties) - 1
+	p.setTrailing(p.tokens[end].Line, func(comment string) {
+		p.fileInfo.Data[owner].Properties[index].Comments = append(p.fileInfo.Data[owner].Properties[index].Comments, comment)
+	})
+}
+
+func accessTypeFromString(access string) AccessType {
+	switch access {
+	case "public":
+		return Public
+	case "protected":
+		return Protected
+	}
+	return Private
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	for _, test := range []struct {
+		source string
+		want   []string
+	}{
+		{"UFoo::Bar->Baz", []string{"ident UFoo", "punct ::", "ident Bar", "punct ->", "ident Baz"}},
+		{"TArray<TSubclassOf<UFoo>>", []string{"ident TArray", "punct <", "ident TSubclassOf", "punct <", "ident UFoo", "punct >", "punct >"}},
+		{"x = 1.5e-3f + 1'000 + .5;", []string{"ident x", "punct =", "number 1.5e-3f", "punct +", "number 1'000", "punct +", "number .5", "punct ;"}},
+		{`L"wide" u8'c' R"x(a "quoted" )x" "esc\"aped"`, []string{`string L"wide"`, `string u8'c'`, `string R"x(a "quoted" )x"`, `string "esc\"aped"`}},
+		{"int A; // trailing\n/** Doc\n * more */ int B;", []string{"ident int", "ident A", "punct ;", "comment // trailing", "comment /** Doc\n * more */", "ident int", "ident B", "punct ;"}},
+		{"#define ONE \\\n  1\nint x; #x", []string{"directive #define ONE \\\n  1", "ident int", "ident x", "punct ;", "punct #", "ident x"}},
+		{"/* open", []string{"comment /* open"}},
+	} {
+		var got []string
+		for _, tok := range tokenize(test.source) {
+			if tok.Kind != TokenEOF {
+				got = append(got, tokenKindName(tok.Kind)+" "+tok.Text)
+			}
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("tokenize(%q)\n got %q\nwant %q", test.source, got, test.want)
+		}
+	}
+}
+
+func TestTokenizeLines(t *testing.T) {
+	tokens := tokenize("/** a\n b */\n#define X \\\n 1\nint\n\"s\"")
+	var lines []int
+	for _, tok := range tokens {
+		lines = append(lines, tok.Line)
+	}
+	if want := []int{1, 3, 5, 6, 6}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines %v, want %v", lines, want)
+	}
+}
+
+func tokenKindName(kind TokenKind) string {
+	return [...]string{"eof", "ident", "number", "string", "punct", "comment", "directive"}[kind]
+}
+
+// typeSummary describes a parsed type on one line: kind, qualified name, parents, properties and functions.
+func typeSummary(d *DataInfo) string {
+	summary := d.Kind() + " " + d.QualifiedName()
+	if len(d.Parents) > 0 {
+		summary += " : " + strings.Join(d.Parents, ", ")
+	}
+	var properties, functions []string
+	for _, prop := range d.Properties {
+		properties = append(properties, prop.Declaration)
+	}
+	for _, function := range d.Functions {
+		functions = append(functions, function.Name)
+	}
+	return summary + fmt.Sprintf(" %q %v", properties, functions)
+}
+
+func TestParseHeader(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		source string
+		want   []string
+	}{
+		{
+			name: "multi-line head",
+			source: `UCLASS(Blueprintable,
+	meta = (DisplayName = "Foo"))
+class FLOWPILOT_API UFoo
+	: public UObject,
+	  public IFooInterface
+{
+	GENERATED_BODY()
+public:
+	UPROPERTY(EditAnywhere)
+	float Speed = 1.f;
+};`,
+			want: []string{`class UFoo : UObject, IFooInterface ["float Speed = 1.f;"] []`},
+		},
+		{
+			name: "inline bodies",
+			source: `struct FBox
+{
+	int32 Get() const { if (Value > 0) { return Value; } return 0; }
+	void Local()
+	{
+		struct FLocal { int A; };
+	}
+	FBox() : Value(0) {}
+	int32 Value;
+};`,
+			want: []string{`struct FBox ["int32 Value;"] [Get Local FBox]`},
+		},
+		{
+			name: "nested types",
+			source: `namespace FlowPilot::Detail
+{
+class UOuter : public UObject
+{
+public:
+	struct FInner
+	{
+		enum class EDeep : uint8 { A, B };
+		int32 X;
+	};
+	void Use(const FInner& Inner);
+};
+}`,
+			want: []string{
+				`class FlowPilot::Detail::UOuter : UObject [] [Use]`,
+				`struct FlowPilot::Detail::UOuter::FInner ["int32 X;"] []`,
+				`enum FlowPilot::Detail::UOuter::FInner::EDeep ["A," "B"] []`,
+			},
+		},
+		{
+			name: "conditional blocks",
+			source: `#ifndef FOO_H
+#define FOO_H
+class FFoo
+{
+public:
+#if WITH_EDITOR
+	void EditorOnly();
+#else
+	void NotEditor();
+#endif
+	void Always();
+};
+#endif`,
+			want: []string{"class FFoo [] [Always]"},
+		},
+		{
+			name: "unbalanced braces",
+			source: `class A {
+public:
+	void F() {
+		if (x) {
+};
+}}}}}
+class B { int X; };
+struct C { int Y;`,
+			want: []string{`class A [] [F]`, `class B ["int X;"] []`, `struct C ["int Y;"] []`},
+		},
+		{
+			name: "delegates and function pointers",
+			source: `class UHolder : public UObject
+{
+public:
+	DECLARE_DYNAMIC_MULTICAST_DELEGATE_OneParam(FOnX, int32, V);
+	void (*Callback)(int);
+	void (UHolder::*Method)(float) = nullptr;
+	void Tick(float Delta);
+	void (*GetHandler())(int);
+	static void (*GetFallback(int32 Index))(float) noexcept;
+	void (*Handlers[2])(int);
+};`,
+			want: []string{`class UHolder : UObject ["void (*Callback)(int);" "void (UHolder::*Method)(float) = nullptr;" "void (*Handlers[2])(int);"] [Tick GetHandler GetFallback]`},
+		},
+	} {
+		var fileInfo FileInfo
+		parseHeader(test.source, &fileInfo)
+		var got []string
+		for i := range fileInfo.Data {
+			got = append(got, typeSummary(&fileInfo.Data[i]))
+		}
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s:\n got %q\nwant %q", test.name, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,323 @@
+package main
+
+import (
+	"bufio"
+	"html"
+	"strconv"
+	"strings"
+)
+
+// htmlRenderer writes standalone HTML pages.
+type htmlRenderer struct {
+	// page being rendered, set on a copy of the renderer by RenderPage
+	page *Page
+}
+
+func (h *htmlRenderer) Extension() string {
+	return ".html"
+}
+
+func (h *htmlRenderer) RenderPage(writer *bufio.Writer, page *Page) error {
+	r := *h
+	r.page = page
+	renderSections(&r, writer, page)
+	return nil
+}
+
+func (h *htmlRenderer) RenderIndex(writer *bufio.Writer, index *IndexPage) error {
+	title := html.EscapeString(index.Title)
+	writer.WriteString("<!DOCTYPE html>\n")
+	writer.WriteString("<html>\n<head>\n")
+	writer.WriteString("<meta charset=\"utf-8\">\n")
+	writer.WriteString("<title>" + title + "</title>\n")
+	writer.WriteString("</head>\n<body>\n")
+	writer.WriteString("<h1>" + title + "</h1>\n")
+
+	for _, list := range []struct {
+		title   string
+		entries []IndexEntry
+	}{{"Classes", index.Classes}, {"Structs", index.Structs}, {"Enums", index.Enums}} {
+		if len(list.entries) == 0 {
+			continue
+		}
+		writer.WriteString("<h2>" + list.title + "</h2>\n")
+		writer.WriteString("<table>\n<tr><th>Name</th><th>File</th><th>Summary</th></tr>\n")
+		for _, entry := range list.entries {
+			writer.WriteString("<tr><td><a href=\"" + html.EscapeString(entry.Href) + "\"><code>" + html.EscapeString(entry.Name) + "</code></a></td>" +
+				"<td><code>" + html.EscapeString(entry.File) + "</code></td><td>" + html.EscapeString(entry.Summary) + "</td></tr>\n")
+		}
+		writer.WriteString("</table>\n")
+	}
+
+	if len(index.Folders) > 0 {
+		writer.WriteString("<h2>Files</h2>\n")
+	}
+	for _, folder := range index.Folders {
+		writer.WriteString("<h3><code>" + html.EscapeString(folder.Name) + "</code></h3>\n")
+		writer.WriteString("<table>\n<tr><th>File</th><th>Summary</th></tr>\n")
+		for _, file := range folder.Files {
+			writer.WriteString("<tr><td><a href=\"" + html.EscapeString(file.Href) + "\"><code>" + html.EscapeString(file.Name) + "</code></a></td>" +
+				"<td>" + html.EscapeString(file.Summary) + "</td></tr>\n")
+		}
+		writer.WriteString("</table>\n")
+	}
+
+	writer.WriteString("</body>\n</html>\n")
+	return nil
+}
+
+func (h *htmlRenderer) link(text string, symbol Symbol) string {
+	return "<a href=\"" + html.EscapeString(h.page.Project.Symbols.Href(h.page.Path, symbol)) + "\">" + text + "</a>"
+}
+
+// code escapes a declaration and links the known types it mentions.
+func (h *htmlRenderer) code(declaration string) string {
+	return h.page.Project.Symbols.Linkify(declaration, html.EscapeString, func(name string, symbol Symbol) string {
+		return h.link(html.EscapeString(name), symbol)
+	})
+}
+
+func (h *htmlRenderer) BeginPage(writer *bufio.Writer, page *Page) {
+	name := html.EscapeString(page.File.Name)
+	writer.WriteString("<!DOCTYPE html>\n")
+	writer.WriteString("<html>\n<head>\n")
+	writer.WriteString("<meta charset=\"utf-8\">\n")
+	writer.WriteString("<title>" + name + "</title>\n")
+	writer.WriteString("</head>\n<body>\n")
+	writer.WriteString("<h1>" + name + "</h1>\n")
+	writer.WriteString("<h2>File Info</h2>\n")
+}
+
+func (h *htmlRenderer) EndPage(writer *bufio.Writer, page *Page) {
+	writer.WriteString("</body>\n</html>\n")
+}
+
+func (h *htmlRenderer) FileSummary(writer *bufio.Writer, f *FileInfo, enums, structs, classes []DataInfo) {
+	writer.WriteString("<p><strong>FileName:</strong> <code>" + html.EscapeString(f.Name) + "</code></p>\n")
+	writer.WriteString("<ul>\n")
+	h.typeList(writer, "Enum List", enums, false)
+	h.typeList(writer, "Struct List", structs, true)
+	h.typeList(writer, "Class List", classes, true)
+	writer.WriteString("</ul>\n")
+}
+
+func (h *htmlRenderer) typeList(writer *bufio.Writer, label string, types []DataInfo, documentedOnly bool) {
+	var links []string
+	for _, d := range types {
+		if !documentedOnly || d.HasDocumentation() {
+			links = append(links, "<a href=\"#"+html.EscapeString(h.page.typeAnchor(&d))+"\"><code>"+html.EscapeString(h.page.typeName(&d))+"</code></a>")
+		}
+	}
+	if len(links) > 0 {
+		writer.WriteString("<li><strong>" + label + ":</strong> " + strings.Join(links, " | ") + "</li>\n")
+	}
+}
+
+func (h *htmlRenderer) TypeHeader(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("<h2 id=\"" + html.EscapeString(h.page.typeAnchor(d)) + "\"><code>" + html.EscapeString(h.page.typeName(d)) + "</code></h2>\n")
+}
+
+func (h *htmlRenderer) EnumHeader(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("<h3 id=\"" + html.EscapeString(h.page.typeAnchor(d)) + "\"><code>" + html.EscapeString(h.page.typeName(d)) + "</code></h3>\n")
+}
+
+func (h *htmlRenderer) Parents(writer *bufio.Writer, d *DataInfo) {
+	if len(d.Parents) == 0 {
+		return
+	}
+	var parents []string
+	for _, parent := range h.page.Project.Parents(d) {
+		code := "<code>" + html.EscapeString(parent) + "</code>"
+		if symbol, ok := h.page.Project.Symbols.Lookup(parent); ok {
+			code = h.link(code, symbol)
+		}
+		parents = append(parents, code)
+	}
+	writer.WriteString("<p><strong>Parent Classes:</strong> " + strings.Join(parents, ", ") + "</p>\n")
+}
+
+func (h *htmlRenderer) Derived(writer *bufio.Writer, derived derivedList) {
+	if len(derived.Names) == 0 {
+		return
+	}
+	var links []string
+	for _, name := range derived.Names {
+		code := "<code>" + html.EscapeString(name) + "</code>"
+		if symbol, ok := h.page.Project.Symbols.Lookup(name); ok {
+			code = h.link(code, symbol)
+		}
+		links = append(links, code)
+	}
+	writer.WriteString("<p><strong>" + derived.Title + ":</strong> " + strings.Join(links, ", ") + "</p>\n")
+}
+
+func (h *htmlRenderer) Badges(writer *bufio.Writer, badges []string) {
+	if len(badges) > 0 {
+		writer.WriteString("<p><strong>Specifiers:</strong> " + html.EscapeString(strings.Join(badges, ", ")) + "</p>\n")
+	}
+}
+
+func (h *htmlRenderer) Description(writer *bufio.Writer, d *DataInfo) {
+	doc := d.Doc()
+	if len(doc.Text) > 0 {
+		writer.WriteString("<p>" + h.textLines(doc.Text) + "</p>\n")
+	}
+	h.docTags(writer, &doc)
+}
+
+func (h *htmlRenderer) textLines(text []string) string {
+	var lines []string
+	for _, line := range text {
+		lines = append(lines, html.EscapeString(line))
+	}
+	return strings.Join(lines, "<br>\n")
+}
+
+// docTags writes the tags of a doc comment: the parameters table, the returned value and the see also links, then
+// the deprecation, notes and warnings as admonition boxes.
+func (h *htmlRenderer) docTags(writer *bufio.Writer, doc *DocComment) {
+	if len(doc.Params) > 0 {
+		writer.WriteString("<p><strong>Parameters:</strong></p>\n")
+		writer.WriteString("<table>\n")
+		writer.WriteString("<thead><tr><th>Name</th><th>Type</th><th>Default</th><th>Description</th></tr></thead>\n")
+		writer.WriteString("<tbody>\n")
+		for _, param := range doc.Params {
+			writer.WriteString("<tr><td>" + h.codeCell(param.Name) + "</td><td>" + h.codeCell(param.Type) + "</td><td>" + h.codeCell(param.Default) + "</td><td>" + html.EscapeString(param.Description) + "</td></tr>\n")
+		}
+		writer.WriteString("</tbody>\n")
+		writer.WriteString("</table>\n")
+	}
+	if doc.Returns != "" {
+		writer.WriteString("<p><strong>Returns:</strong> " + html.EscapeString(doc.Returns) + "</p>\n")
+	}
+	if len(doc.See) > 0 {
+		var links []string
+		for _, ref := range doc.See {
+			code := "<code>" + html.EscapeString(ref) + "</code>"
+			if symbol, ok := h.page.Project.Symbols.LookupReference(ref); ok {
+				code = h.link(code, symbol)
+			}
+			links = append(links, code)
+		}
+		writer.WriteString("<p><strong>See also:</strong> " + strings.Join(links, ", ") + "</p>\n")
+	}
+	if doc.Deprecated {
+		h.admonition(writer, "danger", "Deprecated", doc.DeprecationNotice())
+	}
+	for _, note := range doc.Notes {
+		h.admonition(writer, "note", "Note", note)
+	}
+	for _, warning := range doc.Warnings {
+		h.admonition(writer, "warning", "Warning", warning)
+	}
+}
+
+// codeCell returns a declaration fragment as linked code, or nothing when it is empty.
+func (h *htmlRenderer) codeCell(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "<code>" + h.code(text) + "</code>"
+}
+
+func (h *htmlRenderer) admonition(writer *bufio.Writer, kind string, title string, text string) {
+	writer.WriteString("<div class=\"admonition " + kind + "\"><p><strong>" + title + ":</strong> " + html.EscapeString(text) + "</p></div>\n")
+}
+
+func (h *htmlRenderer) Example(writer *bufio.Writer, example string) {
+	if example != "" {
+		writer.WriteString("<p><strong>Example:</strong></p>\n<pre><code class=\"language-cpp\">" + html.EscapeString(example) + "</code></pre>\n")
+	}
+}
+
+// Diagram writes the diagram for mermaid.js, which renders every element with the "mermaid" class.
+func (h *htmlRenderer) Diagram(writer *bufio.Writer, mermaid string) {
+	if mermaid != "" {
+		writer.WriteString("<pre class=\"mermaid\">\n" + html.EscapeString(mermaid) + "</pre>\n")
+	}
+}
+
+func (h *htmlRenderer) Properties(writer *bufio.Writer, d *DataInfo) {
+	if !d.HasDocumentedProperties() {
+		return
+	}
+
+	writer.WriteString("<h3>Properties</h3>\n")
+	h.propertyGroup(writer, propertyGroups(d))
+}
+
+// heading writes a <hN> heading, or a bold paragraph past h6.
+func (h *htmlRenderer) heading(writer *bufio.Writer, level int, content string) {
+	if level > maxHeadingLevel {
+		writer.WriteString("<p><strong>" + content + "</strong></p>\n")
+		return
+	}
+	tag := "h" + strconv.Itoa(level)
+	writer.WriteString("<" + tag + ">" + content + "</" + tag + ">\n")
+}
+
+// propertyGroup writes the properties of a Category in one code block, then its sub categories under nested headings.
+func (h *htmlRenderer) propertyGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		h.heading(writer, headingLevel(3, group.Depth), html.EscapeString(group.Name))
+	}
+
+	if len(group.Properties) > 0 {
+		writer.WriteString("<pre><code class=\"language-cpp\">")
+		for _, prop := range group.Properties {
+			for _, comm := range prop.Documentation() {
+				writer.WriteString(html.EscapeString("// "+cleanComment(comm)) + "\n")
+			}
+			if prop.Macro != "" {
+				writer.WriteString(html.EscapeString(prop.Macro) + "\n")
+			}
+			writer.WriteString(h.code(prop.Declaration) + "\n\n")
+		}
+		writer.WriteString("</code></pre>\n")
+	}
+
+	for _, sub := range group.Groups {
+		h.propertyGroup(writer, sub)
+	}
+}
+
+func (h *htmlRenderer) Functions(writer *bufio.Writer, d *DataInfo) {
+	if !d.HasDocumentedFunctions() {
+		return
+	}
+
+	writer.WriteString("<h3>Functions</h3>\n")
+	h.functionGroup(writer, functionGroups(d))
+}
+
+// functionGroup writes the functions of a Category, then its sub categories under nested headings.
+func (h *htmlRenderer) functionGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		h.heading(writer, headingLevel(3, group.Depth), html.EscapeString(group.Name))
+	}
+
+	for _, function := range group.Functions {
+		h.heading(writer, headingLevel(4, group.Depth), "<code>"+html.EscapeString(function.Name)+"</code>")
+		h.Badges(writer, function.Specifiers.Badges())
+		doc := function.Doc()
+		writer.WriteString("<blockquote>" + h.textLines(doc.Text) + "</blockquote>\n")
+		writer.WriteString("<pre><code class=\"language-cpp\">" + h.code(function.Declaration) + "</code></pre>\n")
+		h.docTags(writer, &doc)
+		h.Example(writer, function.Example)
+	}
+
+	for _, sub := range group.Groups {
+		h.functionGroup(writer, sub)
+	}
+}
+
+func (h *htmlRenderer) EnumValues(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("<table>\n")
+	writer.WriteString("<thead><tr><th>Value</th><th>Description</th></tr></thead>\n")
+	writer.WriteString("<tbody>\n")
+	for _, prop := range d.Properties {
+		writer.WriteString("<tr><td><code>" + html.EscapeString(enumValueName(&prop)) + "</code></td><td>" + html.EscapeString(enumValueDescription(&prop)) + "</td></tr>\n")
+	}
+	writer.WriteString("</tbody>\n")
+	writer.WriteString("</table>\n")
+}
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// indexTitle is the title of the landing page and of the root of the sidebar.
+const indexTitle = "API Reference"
+
+// Values of output.sidebar, with the manifest written in every folder of pages.
+var sidebarManifestNames = map[string]string{
+	"docusaurus": "_category_.json",
+	"nextra":     "_meta.json",
+}
+
+// IndexPage is the landing page of the documentation: every type alphabetically, then every header by folder.
+type IndexPage struct {
+	// Path of the page relative to the destination folder, with forward slashes
+	Path  string
+	Title string
+	// FrontMatter of the page, without the '---' lines
+	FrontMatter string
+	Classes     []IndexEntry
+	Structs     []IndexEntry
+	Enums       []IndexEntry
+	Folders     []IndexFolder
+	Project     *Project
+}
+
+// IndexEntry is a type or a header listed on the index page.
+type IndexEntry struct {
+	Name string
+	// Href links to the section or page documenting the entry, relative to the index page
+	Href string
+	// File is the path of the header declaring a type relative to the source folder, empty for headers
+	File string
+	// Summary is the first sentence of the documentation, of the first documented type for headers
+	Summary string
+}
+
+// IndexFolder is a folder of the source folder holding headers, Name is relative to the source folder.
+type IndexFolder struct {
+	Name  string
+	Files []IndexEntry
+}
+
+// Index returns the landing page at indexPath, listing the enums and documented types of every page like the symbol index.
+func (p *Project) Index(indexPath string) *IndexPage {
+	index := &IndexPage{
+		Path:        indexPath,
+		Title:       indexTitle,
+		FrontMatter: "title: " + indexTitle + "\n",
+		Project:     p,
+	}
+
+	folders := map[string]*IndexFolder{}
+	var folderNames []string
+	for i := range p.Files {
+		fileInfo := &p.Files[i]
+		page := p.PagePath(fileInfo)
+
+		file := IndexEntry{Name: fileInfo.Name, Href: relativePath(path.Dir(indexPath), page)}
+		for j := range fileInfo.Data {
+			data := &fileInfo.Data[j]
+			if data.Name == "" || (!data.IsEnum && !data.HasDocumentation()) {
+				continue
+			}
+			name := p.Symbols.Name(data)
+			entry := IndexEntry{
+				Name:    name,
+				Href:    p.Symbols.Href(indexPath, Symbol{Name: name, Page: page, Anchor: anchor(name)}),
+				File:    fileInfo.RelPath,
+				Summary: firstSentence(data.Documentation()),
+			}
+			if file.Summary == "" {
+				file.Summary = entry.Summary
+			}
+			switch data.Kind() {
+			case "enum":
+				index.Enums = append(index.Enums, entry)
+			case "struct":
+				index.Structs = append(index.Structs, entry)
+			default:
+				index.Classes = append(index.Classes, entry)
+			}
+		}
+
+		folderName := path.Dir(fileInfo.RelPath)
+		if folderName == "." {
+			folderName = filepath.Base(p.SourceFolder)
+		}
+		folder, ok := folders[folderName]
+		if !ok {
+			folder = &IndexFolder{Name: folderName}
+			folders[folderName] = folder
+			folderNames = append(folderNames, folderName)
+		}
+		folder.Files = append(folder.Files, file)
+	}
+
+	for _, entries := range [][]IndexEntry{index.Classes, index.Structs, index.Enums} {
+		sortEntries(entries)
+	}
+	slices.SortFunc(folderNames, compareNames)
+	for _, name := range folderNames {
+		sortEntries(folders[name].Files)
+		index.Folders = append(index.Folders, *folders[name])
+	}
+	return index
+}
+
+// compareNames orders names alphabetically, ignoring case first.
+func compareNames(a, b string) int {
+	if c := strings.Compare(strings.ToLower(a), strings.ToLower(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+func sortEntries(entries []IndexEntry) {
+	slices.SortStableFunc(entries, func(a, b IndexEntry) int { return compareNames(a.Name, b.Name) })
+}
+
+// firstSentence returns the text of the comments, without their tags, on one line up to the end of the first sentence.
+// A sentence ends with a run of '.', '!' or '?' following a word character and followed by a space, so a "!!" standing
+// alone in the text does not end it.
+func firstSentence(comments []string) string {
+	var lines []string
+	for _, line := range parseDocComment(comments).Text {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	text := strings.Join(lines, " ")
+	for i := 1; i < len(text); i++ {
+		if !isSentenceEnd(text[i]) || !isWordByte(text[i-1]) {
+			continue
+		}
+		end := i + 1
+		for end < len(text) && isSentenceEnd(text[end]) {
+			end++
+		}
+		if end == len(text) || text[end] == ' ' {
+			return text[:end]
+		}
+		i = end - 1
+	}
+	return text
+}
+
+func isSentenceEnd(c byte) bool {
+	return c == '.' || c == '!' || c == '?'
+}
+
+// isWordByte reports whether c is a letter, a digit, an underscore or part of a multi-byte character.
+func isWordByte(c byte) bool {
+	return c == '_' || c >= utf8.RuneSelf || '0' <= c && c <= '9' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+}
+
+// sidebarItem is a page or a sub folder listed by the sidebar manifest of a folder.
+type sidebarItem struct {
+	// Name of the page without extension, or of the folder
+	Name  string
+	Title string
+}
+
+// sidebarManifests returns the manifests of kind, one per folder of pages, so the documentation site lists every
+// page without hand written navigation. titles maps the pages, relative to the destination folder, to their title.
+// The index page is listed first, the other pages and sub folders alphabetically.
+func sidebarManifests(kind string, indexPath string, titles map[string]string) ([]page, error) {
+	manifestName, ok := sidebarManifestNames[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown sidebar %q, expected docusaurus or nextra", kind)
+	}
+
+	items := map[string][]sidebarItem{".": nil}
+	for pagePath, title := range titles {
+		if pagePath == indexPath {
+			continue
+		}
+		folder := path.Dir(pagePath)
+		name := path.Base(pagePath)
+		items[folder] = append(items[folder], sidebarItem{Name: strings.TrimSuffix(name, path.Ext(name)), Title: title})
+		for folder != "." {
+			parent := path.Dir(folder)
+			item := sidebarItem{Name: path.Base(folder), Title: path.Base(folder)}
+			if slices.Contains(items[parent], item) {
+				break
+			}
+			items[parent] = append(items[parent], item)
+			folder = parent
+		}
+	}
+
+	var manifests []page
+	for folder, folderItems := range items {
+		slices.SortFunc(folderItems, func(a, b sidebarItem) int { return compareNames(a.Title, b.Title) })
+
+		label := path.Base(folder)
+		if folder == "." {
+			label = indexTitle
+			if _, ok := titles[indexPath]; ok {
+				folderItems = append([]sidebarItem{{Name: strings.TrimSuffix(indexPath, path.Ext(indexPath)), Title: titles[indexPath]}}, folderItems...)
+			}
+		}
+
+		var content []byte
+		var err error
+		switch kind {
+		case "docusaurus":
+			content, err = json.MarshalIndent(map[string]string{"label": label}, "", "  ")
+		case "nextra":
+			content, err = orderedJSONObject(folderItems)
+		}
+		if err != nil {
+			return nil, err
+		}
+		manifests = append(manifests, page{path.Join(folder, manifestName), append(content, '\n')})
+	}
+	slices.SortFunc(manifests, func(a, b page) int { return strings.Compare(a.Path, b.Path) })
+	return manifests, nil
+}
+
+// orderedJSONObject writes items as a JSON object of names to titles, in the order of items.
+func orderedJSONObject(items []sidebarItem) ([]byte, error) {
+	var builder strings.Builder
+	builder.WriteString("{")
+	for i, item := range items {
+		name, err := json.Marshal(item.Name)
+		if err != nil {
+			return nil, err
+		}
+		title, err := json.Marshal(item.Title)
+		if err != nil {
+			return nil, err
+		}
+		if i > 0 {
+			builder.WriteString(",")
+		}
+		builder.WriteString("\n  " + string(name) + ": " + string(title))
+	}
+	if len(items) > 0 {
+		builder.WriteString("\n")
+	}
+	builder.WriteString("}")
+	return []byte(builder.String()), nil
+}
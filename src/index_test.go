@@ -0,0 +1,27 @@
+package main
+
+import "testing"
+
+func TestFirstSentence(t *testing.T) {
+	for _, test := range []struct {
+		comment string
+		want    string
+	}{
+		{"", ""},
+		{"/** Runs the task. Then stops. */", "Runs the task."},
+		{"/**\n * Runs the task\n * until stopped! Then more.\n */", "Runs the task until stopped!"},
+		{"// Is it done? Maybe.", "Is it done?"},
+		{"/** Wait for it... then go. */", "Wait for it..."},
+		{"/** Really?! Yes. */", "Really?!"},
+		{"/** Speed in m/s. @param Value New speed. */", "Speed in m/s."},
+		{"/** Uses v1.5 of the format */", "Uses v1.5 of the format"},
+		// Punctuation not following a word does not end the sentence
+		{"// Internal Task state - !! Order Important !!", "Internal Task state - !! Order Important !!"},
+		{"/** Flags ... of the task. More. */", "Flags ... of the task."},
+		{"/** Größe. Mehr. */", "Größe."},
+	} {
+		if got := firstSentence(commentLines(test.comment)); got != test.want {
+			t.Errorf("firstSentence(%q) = %q, want %q", test.comment, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"encoding/json"
+)
+
+// Version of the JSON export layout. Bump it whenever a field is renamed, removed or changes meaning.
+const jsonSchemaVersion = 1
+
+// Name of the file written by -format json -combined.
+const jsonIndexFileName = "index.json"
+
+type jsonFileDocument struct {
+	SchemaVersion int       `json:"schemaVersion"`
+	File          *FileInfo `json:"file"`
+}
+
+type jsonIndexDocument struct {
+	SchemaVersion int        `json:"schemaVersion"`
+	Files         []FileInfo `json:"files"`
+}
+
+// jsonFileName returns the export file of a header relative to the destination folder, e.g. Public/Foo.h -> Public/Foo.json.
+func jsonFileName(fileInfo *FileInfo, cfg *Config) string {
+	return cfg.PagePath(fileInfo.RelPath, ".json")
+}
+
+// renderJSON returns the parsed model of one header.
+func renderJSON(fileInfo *FileInfo) ([]byte, error) {
+	return marshalJSON(jsonFileDocument{jsonSchemaVersion, fileInfo})
+}
+
+// renderJSONIndex returns the parsed model of every header in a single document.
+func renderJSONIndex(fileInfoList []FileInfo) ([]byte, error) {
+	return marshalJSON(jsonIndexDocument{jsonSchemaVersion, fileInfoList})
+}
+
+func marshalJSON(document any) ([]byte, error) {
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
@@ -0,0 +1,393 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// markdownRenderer writes plain CommonMark pages, MDX pages are written by the built-in templates.
+// The file summary and each type are generated regions between markers, everything else of an existing page is kept.
+type markdownRenderer struct {
+	// page being rendered and its open generated region, set on a copy of the renderer by RenderPage
+	page   *Page
+	region string
+}
+
+func (m *markdownRenderer) Extension() string {
+	return ".md"
+}
+
+func (m *markdownRenderer) RenderPage(writer *bufio.Writer, page *Page) error {
+	r := *m
+	r.page = page
+
+	var buffer bytes.Buffer
+	pageWriter := bufio.NewWriter(&buffer)
+	renderSections(&r, pageWriter, page)
+	pageWriter.Flush()
+
+	content, err := mergeExisting(page.ExistingPath, buffer.String())
+	if err != nil {
+		return err
+	}
+	writer.WriteString(content)
+	return nil
+}
+
+// beginRegion closes the open generated region and opens the region name.
+func (m *markdownRenderer) beginRegion(writer *bufio.Writer, name string) {
+	m.endRegion(writer)
+	writer.WriteString("\n" + generatedMarker(false, "BEGIN", name) + "\n")
+	m.region = name
+}
+
+func (m *markdownRenderer) endRegion(writer *bufio.Writer) {
+	if m.region != "" {
+		writer.WriteString("\n" + generatedMarker(false, "END", m.region) + "\n")
+		m.region = ""
+	}
+}
+
+func (m *markdownRenderer) RenderIndex(writer *bufio.Writer, index *IndexPage) error {
+	writer.WriteString("# " + index.Title + "\n")
+
+	for _, list := range []struct {
+		title   string
+		entries []IndexEntry
+	}{{"Classes", index.Classes}, {"Structs", index.Structs}, {"Enums", index.Enums}} {
+		if len(list.entries) == 0 {
+			continue
+		}
+		writer.WriteString("\n## " + list.title + "\n\n")
+		writer.WriteString("| Name | File | Summary | \n")
+		writer.WriteString("| :-- | :-- | :-- | \n")
+		for _, entry := range list.entries {
+			writer.WriteString("| [`" + entry.Name + "`](" + entry.Href + ") | `" + entry.File + "` | " + markdownCell(entry.Summary) + " | \n")
+		}
+	}
+
+	if len(index.Folders) > 0 {
+		writer.WriteString("\n## Files\n")
+	}
+	for _, folder := range index.Folders {
+		writer.WriteString("\n### `" + folder.Name + "`\n\n")
+		writer.WriteString("| File | Summary | \n")
+		writer.WriteString("| :-- | :-- | \n")
+		for _, file := range folder.Files {
+			writer.WriteString("| [`" + file.Name + "`](" + file.Href + ") | " + markdownCell(file.Summary) + " | \n")
+		}
+	}
+	return nil
+}
+
+// markdownHeading returns a heading of level, or text as a bold label past the deepest heading.
+func markdownHeading(level int, text string) string {
+	if level > maxHeadingLevel {
+		return "__" + text + "__"
+	}
+	return strings.Repeat("#", level) + " " + text
+}
+
+// markdownCell escapes the pipes of text so it stays in one table cell.
+func markdownCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// markdownCodeCell returns text as code in a table cell, or an empty cell.
+func markdownCodeCell(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "`" + markdownCell(text) + "`"
+}
+
+// typeLink returns a link to the section documenting the type name, or name as code when it is unknown.
+func (m *markdownRenderer) typeLink(name string) string {
+	if symbol, ok := m.page.Project.Symbols.Lookup(name); ok {
+		return "[`" + name + "`](" + m.page.Project.Symbols.Href(m.page.Path, symbol) + ")"
+	}
+	return "`" + name + "`"
+}
+
+// seeLink returns a link to the section documenting the type or member of a @see reference, or ref as code.
+func (m *markdownRenderer) seeLink(ref string) string {
+	if symbol, ok := m.page.Project.Symbols.LookupReference(ref); ok {
+		return "[`" + ref + "`](" + m.page.Project.Symbols.Href(m.page.Path, symbol) + ")"
+	}
+	return "`" + ref + "`"
+}
+
+// typeMentions writes links to the known types used by declarations, which code blocks cannot link themselves.
+func (m *markdownRenderer) typeMentions(writer *bufio.Writer, declarations []string, owner string) {
+	symbols := m.page.typeMentions(declarations, owner)
+	if len(symbols) == 0 {
+		return
+	}
+	var links []string
+	for _, symbol := range symbols {
+		links = append(links, m.typeLink(symbol.Name))
+	}
+	writer.WriteString("\n__Types:__ " + strings.Join(links, ", ") + "\n")
+}
+
+func (m *markdownRenderer) BeginPage(writer *bufio.Writer, page *Page) {
+	writer.WriteString("# " + page.File.Name + "\n")
+
+	m.beginRegion(writer, fileInfoRegion)
+	writer.WriteString("\n## File Info\n")
+}
+
+func (m *markdownRenderer) EndPage(writer *bufio.Writer, page *Page) {
+	m.endRegion(writer)
+}
+
+func (m *markdownRenderer) FileSummary(writer *bufio.Writer, f *FileInfo, enums, structs, classes []DataInfo) {
+	writer.WriteString("\n__FileName:__ `" + f.Name + "`\n")
+
+	if len(enums) > 0 {
+		writer.WriteString("- __Enum List:__ \n")
+
+		writer.WriteString("[ ")
+		for i, e := range enums {
+			isLast := i == len(enums)-1
+			if !isLast {
+				writer.WriteString(fmt.Sprintf("[`" + m.page.typeName(&e) + "`](#" + m.page.typeAnchor(&e) + ") | "))
+			} else {
+				writer.WriteString(fmt.Sprintf("[`" + m.page.typeName(&e) + "`](#" + m.page.typeAnchor(&e) + ")"))
+			}
+		}
+		writer.WriteString(" ]\n")
+	}
+
+	if len(structs) > 0 {
+		writer.WriteString("- __Struct List:__ \n")
+
+		writer.WriteString("[ ")
+		for i, s := range structs {
+			if s.HasDocumentation() {
+				isLast := i == len(structs)-1
+				if !isLast {
+					writer.WriteString(fmt.Sprintf("[`" + m.page.typeName(&s) + "`](#" + m.page.typeAnchor(&s) + ") | "))
+				} else {
+					writer.WriteString(fmt.Sprintf("[`" + m.page.typeName(&s) + "`](#" + m.page.typeAnchor(&s) + ")"))
+				}
+			}
+		}
+		writer.WriteString(" ]\n")
+	}
+
+	if len(classes) > 0 {
+		writer.WriteString("- __Class List:__ \n")
+
+		writer.WriteString("[ ")
+		for i, c := range classes {
+			if c.HasDocumentation() {
+				isLast := i == len(classes)-1
+				if !isLast {
+					writer.WriteString(fmt.Sprintf("[`" + m.page.typeName(&c) + "`](#" + m.page.typeAnchor(&c) + ") | "))
+				} else {
+					writer.WriteString(fmt.Sprintf("[`" + m.page.typeName(&c) + "`](#" + m.page.typeAnchor(&c) + ")"))
+				}
+			}
+		}
+		writer.WriteString(" ]\n")
+	}
+}
+
+func (m *markdownRenderer) TypeHeader(writer *bufio.Writer, d *DataInfo) {
+	m.beginRegion(writer, m.page.typeAnchor(d))
+	writer.WriteString(fmt.Sprintf("\n## `" + m.page.typeName(d) + "` \n\n"))
+}
+
+func (m *markdownRenderer) EnumHeader(writer *bufio.Writer, d *DataInfo) {
+	m.beginRegion(writer, m.page.typeAnchor(d))
+	writer.WriteString(fmt.Sprintf("\n### `" + m.page.typeName(d) + "` \n\n"))
+}
+
+func (m *markdownRenderer) Parents(writer *bufio.Writer, d *DataInfo) {
+	if len(d.Parents) > 0 {
+		writer.WriteString("\n")
+		writer.WriteString("__Parent Classes:__\n")
+		writer.WriteString("[ ")
+		for i, parent := range m.page.Project.Parents(d) {
+			isLast := i == len(d.Parents)-1
+			if !isLast {
+				writer.WriteString(m.typeLink(parent) + ", ")
+			} else {
+				writer.WriteString(m.typeLink(parent))
+			}
+		}
+		writer.WriteString(" ]\n")
+	}
+}
+
+func (m *markdownRenderer) Derived(writer *bufio.Writer, derived derivedList) {
+	if len(derived.Names) == 0 {
+		return
+	}
+	var links []string
+	for _, name := range derived.Names {
+		links = append(links, m.typeLink(name))
+	}
+	writer.WriteString("\n__" + derived.Title + ":__\n")
+	writer.WriteString("[ " + strings.Join(links, ", ") + " ]\n")
+}
+
+func (m *markdownRenderer) Badges(writer *bufio.Writer, badges []string) {
+	if len(badges) > 0 {
+		writer.WriteString("\n__Specifiers:__ " + strings.Join(badges, ", ") + "\n")
+	}
+}
+
+func (m *markdownRenderer) Description(writer *bufio.Writer, d *DataInfo) {
+	doc := d.Doc()
+	if len(doc.Text) > 0 {
+		writer.WriteString("\n")
+		for _, line := range doc.Text {
+			writer.WriteString("" + line + " \n")
+		}
+	}
+	m.docTags(writer, &doc)
+}
+
+// docTags writes the tags of a doc comment: the parameters table, the returned value and the see also links, then
+// the deprecation, notes and warnings as admonitions.
+func (m *markdownRenderer) docTags(writer *bufio.Writer, doc *DocComment) {
+	if len(doc.Params) > 0 {
+		writer.WriteString("\n__Parameters:__\n\n")
+		writer.WriteString("| Name | Type | Default | Description | \n")
+		writer.WriteString("| :-- | :-- | :-- | :-- | \n")
+		for _, param := range doc.Params {
+			writer.WriteString("| " + markdownCodeCell(param.Name) + " | " + markdownCodeCell(param.Type) + " | " + markdownCodeCell(param.Default) + " | " + markdownCell(param.Description) + " | \n")
+		}
+		// A table runs until a blank line
+		writer.WriteString("\n")
+	}
+	if doc.Returns != "" {
+		writer.WriteString("\n__Returns:__ " + doc.Returns + "\n")
+	}
+	if len(doc.See) > 0 {
+		var links []string
+		for _, ref := range doc.See {
+			links = append(links, m.seeLink(ref))
+		}
+		writer.WriteString("\n__See also:__ " + strings.Join(links, ", ") + "\n")
+	}
+	if doc.Deprecated {
+		m.admonition(writer, "Deprecated", doc.DeprecationNotice())
+	}
+	for _, note := range doc.Notes {
+		m.admonition(writer, "Note", note)
+	}
+	for _, warning := range doc.Warnings {
+		m.admonition(writer, "Warning", warning)
+	}
+}
+
+// admonition writes a quote starting with title, plain markdown has no admonitions.
+func (m *markdownRenderer) admonition(writer *bufio.Writer, title string, text string) {
+	writer.WriteString("\n> __" + title + ":__ " + text + "\n")
+}
+
+func (m *markdownRenderer) Example(writer *bufio.Writer, example string) {
+	if example != "" {
+		writer.WriteString("\n__Example:__\n\n```cpp\n" + example + "```\n")
+	}
+}
+
+func (m *markdownRenderer) Diagram(writer *bufio.Writer, mermaid string) {
+	if mermaid != "" {
+		writer.WriteString("\n```mermaid\n" + mermaid + "```\n")
+	}
+}
+
+func (m *markdownRenderer) Properties(writer *bufio.Writer, d *DataInfo) {
+	if d.HasDocumentedProperties() {
+		writer.WriteString("\n")
+		writer.WriteString("### Properties\n")
+		m.propertyGroup(writer, propertyGroups(d))
+	}
+}
+
+// propertyGroup writes the properties of a Category in one code block, then its sub categories under nested headings.
+func (m *markdownRenderer) propertyGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		writer.WriteString("\n" + markdownHeading(headingLevel(3, group.Depth), group.Name) + "\n")
+	}
+
+	if len(group.Properties) > 0 {
+		writer.WriteString("\n```cpp\n")
+		for _, prop := range group.Properties {
+			for _, comm := range prop.Documentation() {
+				writer.WriteString("// " + cleanComment(comm) + " \n")
+			}
+			if prop.Macro != "" {
+				writer.WriteString(prop.Macro + "\n")
+			}
+			writer.WriteString(prop.Declaration + "\n\n")
+		}
+		writer.WriteString("```\n")
+		m.typeMentions(writer, group.Declarations(), group.Owner)
+	}
+
+	for _, sub := range group.Groups {
+		m.propertyGroup(writer, sub)
+	}
+}
+
+func (m *markdownRenderer) Functions(writer *bufio.Writer, d *DataInfo) {
+	if d.HasDocumentedFunctions() {
+		writer.WriteString("\n")
+		writer.WriteString("### Functions\n")
+		m.functionGroup(writer, functionGroups(d))
+	}
+}
+
+// functionGroup writes the functions of a Category, then its sub categories under nested headings.
+func (m *markdownRenderer) functionGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		writer.WriteString("\n" + markdownHeading(headingLevel(3, group.Depth), group.Name) + "\n")
+	}
+
+	if len(group.Functions) > 0 {
+		writer.WriteString("\n")
+	}
+	for _, function := range group.Functions {
+		doc := function.Doc()
+		writer.WriteString(markdownHeading(headingLevel(4, group.Depth), "`"+function.Name+"`") + "\n")
+		if badges := function.Specifiers.Badges(); len(badges) > 0 {
+			m.Badges(writer, badges)
+			writer.WriteString("\n")
+		}
+		for i, line := range doc.Text {
+			isLast := i == len(doc.Text)-1
+			if isLast {
+				writer.WriteString("> " + line + " \n")
+			} else {
+				writer.WriteString("> " + line + " \\\n")
+			}
+		}
+		writer.WriteString("```cpp\n")
+		writer.WriteString(function.Declaration + "\n")
+		writer.WriteString("```\n")
+		m.typeMentions(writer, []string{function.Declaration}, group.Owner)
+		m.docTags(writer, &doc)
+		m.Example(writer, function.Example)
+	}
+
+	for _, sub := range group.Groups {
+		m.functionGroup(writer, sub)
+	}
+}
+
+func (m *markdownRenderer) EnumValues(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString("\n")
+	writer.WriteString("| Value | Description | \n")
+	writer.WriteString("| :-- | :-- | \n")
+
+	for _, prop := range d.Properties {
+		writer.WriteString("| `" + enumValueName(&prop) + "` | " + enumValueDescription(&prop) + " | \n")
+	}
+}
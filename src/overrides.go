@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Override is the hand written documentation of one symbol, for headers whose comments cannot be edited.
+type Override struct {
+	// Description replaces the comments of the symbol
+	Description string `yaml:"description"`
+	// Append is added after the comments, or after Description
+	Append string `yaml:"append"`
+	// Example is C++ code shown under the description of a type or function
+	Example string `yaml:"example"`
+	// Hidden leaves the symbol out of every page
+	Hidden bool `yaml:"hidden"`
+}
+
+// Overrides are keyed by qualified name: 'UFlowPilotTask' for a type, 'UFlowPilotTask::Enter' for a function,
+// property or enumerator. The scoped name, without namespaces, matches too when no key holds the qualified name.
+// An override of a function applies to all its overloads.
+type Overrides map[string]Override
+
+// loadOverrides reads the overrides file of the config, if any.
+func loadOverrides(overridesPath string) (Overrides, error) {
+	if overridesPath == "" {
+		return nil, nil
+	}
+	content, err := os.ReadFile(overridesPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading overrides %s: %w", overridesPath, err)
+	}
+
+	var overrides Overrides
+	if err := yaml.Unmarshal(content, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing overrides %s: %w", overridesPath, err)
+	}
+	return overrides, nil
+}
+
+// comments returns the comments of a symbol with the override applied. Added lines are written as '//' comments.
+func (o Override) comments(comments []string) []string {
+	if o.Description != "" {
+		comments = overrideComments(o.Description)
+	}
+	if o.Append != "" {
+		comments = append(slices.Clip(comments), overrideComments(o.Append)...)
+	}
+	return comments
+}
+
+func overrideComments(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimRight(text, "\n"), "\n") {
+		lines = append(lines, "// "+line)
+	}
+	return lines
+}
+
+// exampleCode returns an example with exactly one final newline, or "".
+func exampleCode(example string) string {
+	if example = strings.TrimRight(example, "\n"); example == "" {
+		return ""
+	}
+	return example + "\n"
+}
+
+// apply returns the types of a file with the overrides applied, and records in used the qualified names of the types
+// each key matched. The types are copied, the slices of data are left untouched.
+func (overrides Overrides) apply(data []DataInfo, used map[string][]string) []DataInfo {
+	var result []DataInfo
+	for _, d := range data {
+		override, ok := overrides.lookup(&d, "", used)
+		if ok {
+			if override.Hidden {
+				continue
+			}
+			d.Comments = override.comments(d.Comments)
+			d.Example = exampleCode(override.Example)
+		}
+
+		var properties []PropertyInfo
+		for _, prop := range d.Properties {
+			if override, ok := overrides.lookup(&d, propertyName(prop.Declaration), used); ok {
+				if override.Hidden {
+					continue
+				}
+				prop.Comments = override.comments(prop.Comments)
+			}
+			properties = append(properties, prop)
+		}
+		d.Properties = properties
+
+		var functions []FunctionInfo
+		for _, function := range d.Functions {
+			if override, ok := overrides.lookup(&d, function.Name, used); ok {
+				if override.Hidden {
+					continue
+				}
+				function.Comments = override.comments(function.Comments)
+				function.Example = exampleCode(override.Example)
+			}
+			functions = append(functions, function)
+		}
+		d.Functions = functions
+
+		result = append(result, d)
+	}
+	return result
+}
+
+// lookup returns the override of the type d, or of its member when member is not empty. The key is the qualified
+// name, or else the scoped name of d, followed by '::' and the member.
+func (overrides Overrides) lookup(d *DataInfo, member string, used map[string][]string) (Override, bool) {
+	for _, name := range []string{d.QualifiedName(), d.ScopedName()} {
+		key := name
+		if member != "" {
+			key += "::" + member
+		}
+		if override, ok := overrides[key]; ok {
+			if !slices.Contains(used[key], d.QualifiedName()) {
+				used[key] = append(used[key], d.QualifiedName())
+			}
+			return override, true
+		}
+	}
+	return Override{}, false
+}
+
+// unused returns the keys of the overrides matching no symbol, sorted.
+func (overrides Overrides) unused(used map[string][]string) []string {
+	var keys []string
+	for key := range overrides {
+		if len(used[key]) == 0 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ambiguous returns the keys of the overrides applied to types of different qualified names, sorted, e.g. 'FState'
+// for both 'A::FState' and 'B::FState'. Such keys need the qualified name.
+func (overrides Overrides) ambiguous(used map[string][]string) []string {
+	var keys []string
+	for key, names := range used {
+		if len(names) > 1 {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// propertyName returns the name declared by a property or enumerator declaration: the last identifier before its
+// initializer, array size, bit field width or a trailing macro call such as UMETA(...), e.g. 'Speed' for
+// 'float Speed = 1.f;'. The name of a function pointer is found inside its declarator, as in 'void (*Callback)(int);'.
+func propertyName(declaration string) string {
+	name := ""
+	depth := 0
+	declarators := 0
+	tokens := tokenize(declaration)
+	for i, tok := range tokens {
+		if tok.Kind == TokenPunct {
+			switch tok.Text {
+			case "(":
+				if depth <= 0 && opensDeclarator(tokens, i) {
+					declarators++
+					continue
+				}
+				depth++
+			case "<":
+				depth++
+			case ")":
+				if depth <= 0 && declarators > 0 {
+					declarators--
+					continue
+				}
+				depth--
+			case ">":
+				depth--
+			case "=", "{", "[", ":", ";", ",":
+				if depth <= 0 {
+					return name
+				}
+			}
+		}
+		if tok.Kind == TokenIdent && depth <= 0 {
+			if name != "" && i+1 < len(tokens) && tokens[i+1].Text == "(" && !opensDeclarator(tokens, i+1) {
+				return name
+			}
+			name = tok.Text
+		}
+	}
+	return name
+}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// overrideSource declares a type of the same scoped name in two namespaces, and a type outside of any namespace.
+const overrideSource = `namespace A
+{
+class UTask
+{
+public:
+	/** Speed. */
+	float Speed;
+	int32 Internal;
+	void Run();
+	void Run(int32 Count);
+};
+}
+namespace B
+{
+class UTask
+{
+public:
+	void Run();
+};
+}
+class FGlobal
+{
+public:
+	int32 Value;
+};`
+
+// overrideSummary describes the documented symbols of data: one line per type and member with its comments.
+func overrideSummary(data []DataInfo) []string {
+	var summary []string
+	for _, d := range data {
+		summary = append(summary, d.QualifiedName()+" "+fmt.Sprint(d.Comments))
+		for _, prop := range d.Properties {
+			summary = append(summary, d.QualifiedName()+"::"+propertyName(prop.Declaration)+" "+fmt.Sprint(prop.Comments))
+		}
+		for _, function := range d.Functions {
+			summary = append(summary, d.QualifiedName()+"::"+function.Name+"() "+fmt.Sprint(function.Comments))
+		}
+	}
+	return summary
+}
+
+func TestOverridesApply(t *testing.T) {
+	for _, test := range []struct {
+		name      string
+		overrides Overrides
+		want      []string
+		unused    []string
+		ambiguous []string
+	}{
+		{
+			name: "qualified names",
+			overrides: Overrides{
+				"A::UTask":           {Description: "Task of A."},
+				"A::UTask::Speed":    {Append: "In m/s."},
+				"A::UTask::Internal": {Hidden: true},
+				"B::UTask::Run":      {Description: "Runs B."},
+				"FGlobal::Value":     {Description: "Value."},
+			},
+			want: []string{
+				"A::UTask [// Task of A.]",
+				"A::UTask::Speed [/** Speed. */ // In m/s.]",
+				"A::UTask::Run() []",
+				"A::UTask::Run() []",
+				"B::UTask []",
+				"B::UTask::Run() [// Runs B.]",
+				"FGlobal []",
+				"FGlobal::Value [// Value.]",
+			},
+		},
+		{
+			name: "scoped name fallback",
+			overrides: Overrides{
+				"UTask":   {Hidden: true},
+				"FGlobal": {Description: "Global."},
+			},
+			want: []string{
+				"FGlobal [// Global.]",
+				"FGlobal::Value []",
+			},
+			ambiguous: []string{"UTask"},
+		},
+		{
+			name: "qualified name before scoped name",
+			overrides: Overrides{
+				"A::UTask::Run": {Description: "Runs A."},
+				"UTask::Run":    {Description: "Runs any task."},
+			},
+			want: []string{
+				"A::UTask []",
+				"A::UTask::Speed [/** Speed. */]",
+				"A::UTask::Internal []",
+				"A::UTask::Run() [// Runs A.]",
+				"A::UTask::Run() [// Runs A.]",
+				"B::UTask []",
+				"B::UTask::Run() [// Runs any task.]",
+				"FGlobal []",
+				"FGlobal::Value []",
+			},
+		},
+		{
+			name: "unused keys",
+			overrides: Overrides{
+				"A::UTask::Renamed": {Description: "Gone."},
+				"C::UTask":          {Hidden: true},
+				"FGlobal::Value":    {Hidden: true},
+			},
+			want: []string{
+				"A::UTask []",
+				"A::UTask::Speed [/** Speed. */]",
+				"A::UTask::Internal []",
+				"A::UTask::Run() []",
+				"A::UTask::Run() []",
+				"B::UTask []",
+				"B::UTask::Run() []",
+				"FGlobal []",
+			},
+			unused: []string{"A::UTask::Renamed", "C::UTask"},
+		},
+	} {
+		var fileInfo FileInfo
+		parseHeader(overrideSource, &fileInfo)
+		before := overrideSummary(fileInfo.Data)
+
+		used := map[string][]string{}
+		if got := overrideSummary(test.overrides.apply(fileInfo.Data, used)); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%s:\n got %q\nwant %q", test.name, got, test.want)
+		}
+		if got := overrideSummary(fileInfo.Data); !reflect.DeepEqual(got, before) {
+			t.Errorf("%s: apply changed the parsed types:\n got %q\nwant %q", test.name, got, before)
+		}
+		if got := test.overrides.unused(used); !reflect.DeepEqual(got, test.unused) {
+			t.Errorf("%s: unused keys %q, want %q", test.name, got, test.unused)
+		}
+		if got := test.overrides.ambiguous(used); !reflect.DeepEqual(got, test.ambiguous) {
+			t.Errorf("%s: ambiguous keys %q, want %q", test.name, got, test.ambiguous)
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// fileInfoRegion is the generated region holding the file summary, the other regions are named after the anchor of their type.
+const fileInfoRegion = "file-info"
+
+// generatedMarker returns the comment opening (BEGIN) or closing (END) the generated region name: an MDX expression
+// comment, or an HTML comment for plain markdown.
+func generatedMarker(mdx bool, kind string, name string) string {
+	if mdx {
+		return "{/* " + kind + " GENERATED " + name + " */}"
+	}
+	return "<!-- " + kind + " GENERATED " + name + " -->"
+}
+
+var generatedMarkerPattern = regexp.MustCompile(`^(?:\{/\*|<!--)\s*(BEGIN|END) GENERATED (\S+)\s*(?:\*/\}|-->)$`)
+
+// pageSegment is a generated region of a page, markers included, or the hand written text between two regions.
+type pageSegment struct {
+	// Region is the name of the region, with '#2', '#3', ... for the next regions of the same name. Empty for text.
+	Region string
+	Text   string
+}
+
+// splitPage splits a markdown page into its front matter, without the '---' lines, and its segments.
+func splitPage(content string) (frontMatter string, hasFrontMatter bool, segments []pageSegment) {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				frontMatter = strings.Join(lines[1:i], "")
+				hasFrontMatter = true
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+
+	counts := map[string]int{}
+	var text strings.Builder
+	region := ""
+	flush := func() {
+		if text.Len() > 0 {
+			segments = append(segments, pageSegment{Region: region, Text: text.String()})
+			text.Reset()
+		}
+	}
+	for _, line := range lines {
+		match := generatedMarkerPattern.FindStringSubmatch(strings.TrimSpace(line))
+		switch {
+		case match != nil && match[1] == "BEGIN" && region == "":
+			flush()
+			counts[match[2]]++
+			region = match[2]
+			if counts[match[2]] > 1 {
+				region += "#" + strconv.Itoa(counts[match[2]])
+			}
+			text.WriteString(line)
+		case match != nil && match[1] == "END" && region != "" && strings.SplitN(region, "#", 2)[0] == match[2]:
+			text.WriteString(line)
+			flush()
+			region = ""
+		default:
+			text.WriteString(line)
+		}
+	}
+	// An unterminated region runs to the end of the page
+	flush()
+	return frontMatter, hasFrontMatter, segments
+}
+
+// mergeExisting returns rendered merged into the page left at existingPath by a previous run, see mergePage.
+func mergeExisting(existingPath string, rendered string) (string, error) {
+	if existingPath == "" {
+		return rendered, nil
+	}
+	existing, err := os.ReadFile(existingPath)
+	if os.IsNotExist(err) {
+		return rendered, nil
+	} else if err != nil {
+		return "", fmt.Errorf("reading %s: %w", existingPath, err)
+	}
+	return mergePage(string(existing), rendered), nil
+}
+
+// mergePage replaces the generated regions of an existing page with those of rendered and keeps everything else: the
+// hand written text before, between and after the regions, and the front matter fields the config does not generate.
+// Regions no longer rendered are dropped, new ones are inserted after the region preceding them in rendered.
+// A page without any region, from before the markers, keeps its text above "## File Info".
+func mergePage(existing, rendered string) string {
+	existingFrontMatter, hasExistingFrontMatter, existingSegments := splitPage(existing)
+	frontMatter, hasFrontMatter, segments := splitPage(rendered)
+
+	var builder strings.Builder
+	if hasFrontMatter {
+		if hasExistingFrontMatter {
+			frontMatter = mergeFrontMatter(existingFrontMatter, frontMatter)
+		}
+		builder.WriteString("---\n" + frontMatter + "---\n")
+	}
+
+	hasRegions := false
+	for _, segment := range existingSegments {
+		if segment.Region != "" {
+			hasRegions = true
+		}
+	}
+	if !hasRegions {
+		body := ""
+		for _, segment := range existingSegments {
+			body += segment.Text
+		}
+		if i := strings.Index(body, "## File Info"); i >= 0 {
+			body = body[:strings.LastIndex(body[:i], "\n")+1]
+		}
+		if strings.TrimSpace(body) == "" {
+			for _, segment := range segments {
+				builder.WriteString(segment.Text)
+			}
+			return builder.String()
+		}
+
+		builder.WriteString(body)
+		if !strings.HasSuffix(body, "\n\n") {
+			builder.WriteString("\n")
+		}
+		for i, segment := range segments {
+			if segment.Region != "" || i > 0 {
+				builder.WriteString(segment.Text)
+			}
+		}
+		return builder.String()
+	}
+
+	regions := map[string]string{}
+	for _, segment := range segments {
+		if segment.Region != "" {
+			regions[segment.Region] = segment.Text
+		}
+	}
+
+	var merged []pageSegment
+	for _, segment := range existingSegments {
+		if segment.Region == "" {
+			merged = append(merged, segment)
+		} else if text, ok := regions[segment.Region]; ok {
+			merged = append(merged, pageSegment{Region: segment.Region, Text: text})
+		}
+	}
+
+	position := -1
+	for _, segment := range segments {
+		if segment.Region == "" {
+			continue
+		}
+		if i := segmentIndex(merged, segment.Region); i >= 0 {
+			position = i
+			continue
+		}
+
+		if position >= 0 {
+			merged = slices.Insert(merged, position+1, pageSegment{Text: "\n"}, segment)
+			position += 2
+		} else {
+			first := len(merged)
+			for i := range merged {
+				if merged[i].Region != "" {
+					first = i
+					break
+				}
+			}
+			merged = slices.Insert(merged, first, segment, pageSegment{Text: "\n"})
+			position = first
+		}
+	}
+
+	for _, segment := range merged {
+		builder.WriteString(segment.Text)
+	}
+	return builder.String()
+}
+
+func segmentIndex(segments []pageSegment, region string) int {
+	for i := range segments {
+		if segments[i].Region == region {
+			return i
+		}
+	}
+	return -1
+}
+
+// mergeFrontMatter returns the generated front matter with the fields of existing it does not set. Generated fields
+// take their new value. When existing has no other field, or either side is not a YAML mapping, generated is returned as is.
+func mergeFrontMatter(existing, generated string) string {
+	var existingDocument, generatedDocument yaml.Node
+	if err := yaml.Unmarshal([]byte(existing), &existingDocument); err != nil || len(existingDocument.Content) == 0 {
+		return generated
+	}
+	if err := yaml.Unmarshal([]byte(generated), &generatedDocument); err != nil {
+		return generated
+	}
+	existingFields := existingDocument.Content[0]
+	if existingFields.Kind != yaml.MappingNode {
+		return generated
+	}
+	generatedFields := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	if len(generatedDocument.Content) > 0 {
+		if generatedDocument.Content[0].Kind != yaml.MappingNode {
+			return generated
+		}
+		generatedFields = generatedDocument.Content[0]
+	}
+
+	generatedValues := map[string]*yaml.Node{}
+	for i := 0; i+1 < len(generatedFields.Content); i += 2 {
+		generatedValues[generatedFields.Content[i].Value] = generatedFields.Content[i+1]
+	}
+
+	// Fields keep the order of the existing page, new generated ones come last
+	kept := false
+	merged := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	seen := map[string]bool{}
+	for i := 0; i+1 < len(existingFields.Content); i += 2 {
+		key := existingFields.Content[i]
+		value := existingFields.Content[i+1]
+		if generatedValue, ok := generatedValues[key.Value]; ok {
+			value = generatedValue
+		} else {
+			kept = true
+		}
+		seen[key.Value] = true
+		merged.Content = append(merged.Content, key, value)
+	}
+	if !kept {
+		return generated
+	}
+	for i := 0; i+1 < len(generatedFields.Content); i += 2 {
+		if !seen[generatedFields.Content[i].Value] {
+			merged.Content = append(merged.Content, generatedFields.Content[i], generatedFields.Content[i+1])
+		}
+	}
+
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(merged); err != nil {
+		return generated
+	}
+	encoder.Close()
+	return buffer.String()
+}
@@ -0,0 +1,74 @@
+package main
+
+import "testing"
+
+// region returns a generated region of a markdown page holding body.
+func region(name, body string) string {
+	return generatedMarker(false, "BEGIN", name) + "\n" + body + generatedMarker(false, "END", name) + "\n"
+}
+
+func TestMergePage(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		existing string
+		rendered string
+		want     string
+	}{
+		{
+			name:     "regions replaced, hand written text kept",
+			existing: "Intro\n" + region("file-info", "old info\n") + "\nBetween\n" + region("ufoo", "old foo\n") + "\nOutro\n",
+			rendered: region("file-info", "new info\n") + "\n" + region("ufoo", "new foo\n"),
+			want:     "Intro\n" + region("file-info", "new info\n") + "\nBetween\n" + region("ufoo", "new foo\n") + "\nOutro\n",
+		},
+		{
+			name:     "region no longer rendered dropped",
+			existing: region("file-info", "info\n") + "\n" + region("uold", "old\n") + "\nNotes\n",
+			rendered: region("file-info", "info\n"),
+			want:     region("file-info", "info\n") + "\n\nNotes\n",
+		},
+		{
+			name:     "new region after the one preceding it",
+			existing: region("file-info", "info\n") + "\nAbout A\n" + region("ua", "a\n") + "\nAbout C\n" + region("uc", "c\n"),
+			rendered: region("file-info", "info\n") + "\n" + region("ua", "a\n") + "\n" + region("ub", "b\n") + "\n" + region("uc", "c\n"),
+			want:     region("file-info", "info\n") + "\nAbout A\n" + region("ua", "a\n") + "\n" + region("ub", "b\n") + "\nAbout C\n" + region("uc", "c\n"),
+		},
+		{
+			name:     "new first region before the existing ones",
+			existing: "Intro\n" + region("ub", "b\n"),
+			rendered: region("ua", "a\n") + "\n" + region("ub", "b\n"),
+			want:     "Intro\n" + region("ua", "a\n") + "\n" + region("ub", "b\n"),
+		},
+		{
+			name:     "regions of the same name matched in order",
+			existing: region("ua", "first\n") + "\nKept\n" + region("ua", "second\n"),
+			rendered: region("ua", "new first\n") + "\n" + region("ua", "new second\n"),
+			want:     region("ua", "new first\n") + "\nKept\n" + region("ua", "new second\n"),
+		},
+		{
+			name:     "front matter fields kept and updated",
+			existing: "---\ntitle: Old.h\nsidebar_position: 3\n---\n" + region("file-info", "info\n"),
+			rendered: "---\ntitle: New.h\ndescription: Reference page\n---\n" + region("file-info", "info\n"),
+			want:     "---\ntitle: New.h\nsidebar_position: 3\ndescription: Reference page\n---\n" + region("file-info", "info\n"),
+		},
+		{
+			name:     "page from before the markers keeps its text above the file info",
+			existing: "Hand written\n\n## File Info\n\nold info\n",
+			rendered: "\n" + region("file-info", "## File Info\n") + "\n" + region("ua", "a\n"),
+			want:     "Hand written\n\n" + region("file-info", "## File Info\n") + "\n" + region("ua", "a\n"),
+		},
+		{
+			name:     "page from before the markers without text is replaced",
+			existing: "## File Info\n\nold info\n",
+			rendered: region("file-info", "## File Info\n"),
+			want:     region("file-info", "## File Info\n"),
+		},
+	} {
+		got := mergePage(test.existing, test.rendered)
+		if got != test.want {
+			t.Errorf("%s:\n got %q\nwant %q", test.name, got, test.want)
+		}
+		if again := mergePage(got, test.rendered); again != got {
+			t.Errorf("%s: merging twice changes the page:\n got %q\nwant %q", test.name, again, got)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package main
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// Project is the model of every header of the source folder. Commands build it completely before rendering
+// anything, so a page can use what the other headers declare.
+type Project struct {
+	SourceFolder string
+	Config       *Config
+	// Files are the parsed headers, in the order of the folder walk
+	Files []FileInfo
+	// Symbols locates the page and anchor of every documented type
+	Symbols *SymbolIndex
+	// types are the classes and structs of every file by qualified name, the first one wins
+	types map[string]*DataInfo
+	// children maps a parent, see parentName, to the classes and structs listing it, in walk order
+	children map[string][]*DataInfo
+	// parsed are the headers as parsed, before the overrides, by relative path, and cacheKey the key of the parse cache
+	parsed   map[string]cacheEntry
+	cacheKey string
+}
+
+// newProject indexes parsed headers.
+func newProject(sourceFolder string, cfg *Config, files []FileInfo) *Project {
+	project := &Project{
+		SourceFolder: sourceFolder,
+		Config:       cfg,
+		Files:        files,
+		Symbols:      buildSymbolIndex(files, cfg),
+		types:        map[string]*DataInfo{},
+		children:     map[string][]*DataInfo{},
+	}
+
+	for i := range files {
+		for j := range files[i].Data {
+			data := &files[i].Data[j]
+			if data.IsEnum || data.Name == "" {
+				continue
+			}
+			if _, exists := project.types[data.QualifiedName()]; !exists {
+				project.types[data.QualifiedName()] = data
+			}
+		}
+	}
+	for i := range files {
+		for j := range files[i].Data {
+			data := &files[i].Data[j]
+			if data.IsEnum || data.Name == "" {
+				continue
+			}
+			for _, parent := range data.Parents {
+				name := project.parentName(data, parent)
+				project.children[name] = append(project.children[name], data)
+			}
+		}
+	}
+	return project
+}
+
+// parentName returns the qualified name of the class or struct of the project a parent of d names, looked up from
+// the scope enclosing d outwards as in C++: 'B' in 'X::A' is 'X::B', then 'B'. A parent the project does not declare
+// keeps its name as written, without template arguments.
+func (p *Project) parentName(d *DataInfo, parent string) string {
+	name := parent
+	if i := strings.Index(name, "<"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.TrimPrefix(strings.TrimSpace(name), "::")
+
+	scope := joinScope(d.Namespace, d.Outer)
+	for {
+		if _, ok := p.types[joinScope(scope, name)]; ok {
+			return joinScope(scope, name)
+		}
+		if scope == "" {
+			return name
+		}
+		if i := strings.LastIndex(scope, "::"); i >= 0 {
+			scope = scope[:i]
+		} else {
+			scope = ""
+		}
+	}
+}
+
+// baseTypeName returns the name a parent refers to, without namespace or template arguments: 'UE::TBase<UFoo>' -> 'TBase'.
+func baseTypeName(name string) string {
+	if i := strings.Index(name, "<"); i >= 0 {
+		name = name[:i]
+	}
+	if i := strings.LastIndex(name, "::"); i >= 0 {
+		name = name[i+2:]
+	}
+	return strings.TrimSpace(name)
+}
+
+// loadProject collects and parses every header of the source folder, the first phase of every command.
+// Headers that fail to parse are reported, counted and left out of the project.
+func loadProject(opts *options) (*Project, int, error) {
+	fileInfoList, err := collectFiles(opts.SourceFolder, &opts.Config)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	maxAccess, err := opts.Config.MaxAccess()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Unchanged headers are read from the cache of the previous run, unless -force
+	key := ""
+	if opts.DestFolder != "" {
+		key = cacheKey(&opts.Config)
+	}
+	cache := newParseCache(key)
+	if !opts.Force {
+		cache = loadCache(opts.DestFolder, key)
+	}
+
+	// Headers are parsed in parallel, errors are reported afterwards in the order of the walk
+	hashes := make([]string, len(fileInfoList))
+	cached := make([]bool, len(fileInfoList))
+	errs := make([]error, len(fileInfoList))
+	forEachParallel(len(fileInfoList), opts.Jobs, func(i int) {
+		hashes[i], cached[i], errs[i] = cache.parseFile(&fileInfoList[i], &opts.Config)
+	})
+
+	overrides, err := loadOverrides(opts.Config.Overrides)
+	if err != nil {
+		return nil, 0, err
+	}
+	used := map[string][]string{}
+
+	failures := 0
+	parsed := fileInfoList[:0]
+	entries := map[string]cacheEntry{}
+	for i := range fileInfoList {
+		if cached[i] {
+			logDebug("Unchanged file: %s\n", fileInfoList[i].Name)
+		} else {
+			logDebug("Processing file: %s\n", fileInfoList[i].Name)
+		}
+		if errs[i] != nil {
+			logError("Error: %v\n", errs[i])
+			failures++
+			continue
+		}
+		fileInfoList[i].FilterAccess(maxAccess)
+		entries[fileInfoList[i].RelPath] = cacheEntry{Hash: hashes[i], Types: fileInfoList[i].Data}
+		if overrides != nil {
+			fileInfoList[i].Data = overrides.apply(fileInfoList[i].Data, used)
+		}
+		for _, warning := range docWarnings(&fileInfoList[i]) {
+			logWarn("%s\n", warning)
+		}
+		parsed = append(parsed, fileInfoList[i])
+	}
+	for _, key := range overrides.unused(used) {
+		logWarn("override %s matches no type or member\n", key)
+	}
+	for _, key := range overrides.ambiguous(used) {
+		logWarn("override %s matches %s, key it by qualified name\n", key, strings.Join(used[key], ", "))
+	}
+	parsed, collisions := dropCollisions(parsed, opts)
+	project := newProject(opts.SourceFolder, &opts.Config, parsed)
+	project.parsed = entries
+	project.cacheKey = key
+	return project, failures + collisions, nil
+}
+
+// dropCollisions reports and leaves out the headers whose output file is already written by an earlier header, or is
+// the index page. Paths are compared ignoring case, as on the file systems of Windows and macOS.
+func dropCollisions(files []FileInfo, opts *options) ([]FileInfo, int) {
+	owners := map[string]string{}
+	if opts.Format == "json" && opts.Combined {
+		return files, 0
+	} else if opts.Format != "json" && opts.Config.Output.Index {
+		owners[strings.ToLower("index"+opts.Config.Output.Extension)] = "the index page"
+	}
+
+	collisions := 0
+	kept := files[:0]
+	for i := range files {
+		outputFile := pageRelPath(&files[i], &opts.Config)
+		if opts.Format == "json" {
+			outputFile = jsonFileName(&files[i], &opts.Config)
+		}
+		if owner, exists := owners[strings.ToLower(outputFile)]; exists {
+			logError("Error: %s and %s are both written to %s, change output.layout or output.paths\n", owner, files[i].RelPath, outputFile)
+			collisions++
+			continue
+		}
+		owners[strings.ToLower(outputFile)] = files[i].RelPath
+		kept = append(kept, files[i])
+	}
+	return kept, collisions
+}
+
+// PagePath returns the page of fileInfo relative to the destination folder, with forward slashes.
+func (p *Project) PagePath(fileInfo *FileInfo) string {
+	return pageRelPath(fileInfo, p.Config)
+}
+
+// Parents returns the parents of d as pages show them: a parent declared by the project by the name it is documented
+// under, followed by the template arguments as written, others as written.
+func (p *Project) Parents(d *DataInfo) []string {
+	var parents []string
+	for _, parent := range d.Parents {
+		if parentType, ok := p.types[p.parentName(d, parent)]; ok {
+			arguments := ""
+			if i := strings.Index(parent, "<"); i >= 0 {
+				arguments = parent[i:]
+			}
+			parent = p.Symbols.Name(parentType) + arguments
+		}
+		parents = append(parents, parent)
+	}
+	return parents
+}
+
+// derivedList is the reverse inheritance shown on the page of a type.
+type derivedList struct {
+	// Title is "Implemented By" for interfaces, "Derived Classes" otherwise
+	Title string
+	Names []string
+}
+
+// isInterface reports whether name follows the UE naming of the native side of an interface, e.g. 'IFlowPilotOwner'.
+func isInterface(name string) bool {
+	runes := []rune(name)
+	return len(runes) > 1 && runes[0] == 'I' && unicode.IsUpper(runes[1])
+}
+
+// Derived lists every class and struct of the project inheriting from d, directly first, then transitively, by the
+// name they are documented under. For an interface they are its implementers. The 'U' side of a UINTERFACE lists the
+// implementers of its 'I' side.
+func (p *Project) Derived(d *DataInfo) derivedList {
+	name := d.Name
+	if !isInterface(name) && strings.HasPrefix(name, "U") && slices.ContainsFunc(d.Parents, func(parent string) bool { return baseTypeName(parent) == "UInterface" }) {
+		name = "I" + name[1:]
+	}
+
+	list := derivedList{Title: "Derived Classes"}
+	if isInterface(name) {
+		list.Title = "Implemented By"
+	}
+
+	start := p.parentName(d, name)
+	if name == d.Name {
+		start = d.QualifiedName()
+	}
+	seen := map[string]bool{start: true, d.QualifiedName(): true}
+	queue := []string{start}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, child := range p.children[current] {
+			if !seen[child.QualifiedName()] {
+				seen[child.QualifiedName()] = true
+				list.Names = append(list.Names, p.Symbols.Name(child))
+				queue = append(queue, child.QualifiedName())
+			}
+		}
+	}
+	return list
+}
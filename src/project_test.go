@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+// writeHeaders writes count headers into a new folder, each class deriving from the one of the previous header, so
+// pages link to each other.
+func writeHeaders(t *testing.T, count int) string {
+	t.Helper()
+	folder := t.TempDir()
+	for i := range count {
+		parent := "UObject"
+		if i > 0 {
+			parent = fmt.Sprintf("UTask%02d", i-1)
+		}
+		header := fmt.Sprintf(`#pragma once
+
+/** Task number %[1]d. */
+UCLASS()
+class UTask%02[1]d : public %[2]s
+{
+	GENERATED_BODY()
+public:
+	/** Speed of the task. */
+	UPROPERTY(EditAnywhere)
+	float Speed = %[1]d.f;
+
+	/** Runs the task. @param Count Times to run. */
+	UFUNCTION(BlueprintCallable)
+	void Run(int32 Count);
+};
+`, i, parent)
+		name := filepath.Join(folder, fmt.Sprintf("Task%02d.h", i))
+		if err := os.WriteFile(name, []byte(header), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return folder
+}
+
+// loadWithJobs parses folder as the check command does, with jobs workers.
+func loadWithJobs(t *testing.T, folder string, jobs int) (*options, *Project) {
+	t.Helper()
+	opts, err := parseOptions(findCommand("check"), []string{"-q", "-j", strconv.Itoa(jobs), folder})
+	if err != nil {
+		t.Fatal(err)
+	}
+	project, failures, err := loadProject(opts)
+	if err != nil || failures != 0 {
+		t.Fatalf("loadProject with -j %d: %d failures, %v", jobs, failures, err)
+	}
+	return opts, project
+}
+
+func TestLoadProjectParallel(t *testing.T) {
+	folder := writeHeaders(t, 24)
+	_, sequential := loadWithJobs(t, folder, 1)
+	_, parallel := loadWithJobs(t, folder, 8)
+
+	if len(parallel.Files) != 24 {
+		t.Fatalf("got %d files, want 24", len(parallel.Files))
+	}
+	for i := range parallel.Files {
+		if want := fmt.Sprintf("Task%02d.h", i); parallel.Files[i].Name != want {
+			t.Errorf("file %d is %s, want %s", i, parallel.Files[i].Name, want)
+		}
+		if !reflect.DeepEqual(parallel.Files[i].Data, sequential.Files[i].Data) {
+			t.Errorf("%s parses differently with -j 8", parallel.Files[i].Name)
+		}
+	}
+}
+
+func TestRenderPagesParallel(t *testing.T) {
+	folder := writeHeaders(t, 24)
+	opts, project := loadWithJobs(t, folder, 1)
+	sequential, failures := renderPages(opts, project)
+	if failures != 0 {
+		t.Fatalf("renderPages with -j 1: %d failures", failures)
+	}
+
+	opts, project = loadWithJobs(t, folder, 8)
+	parallel, failures := renderPages(opts, project)
+	if failures != 0 {
+		t.Fatalf("renderPages with -j 8: %d failures", failures)
+	}
+
+	if len(parallel) != len(sequential) {
+		t.Fatalf("got %d pages with -j 8, want %d", len(parallel), len(sequential))
+	}
+	for i := range parallel {
+		if parallel[i].Path != sequential[i].Path {
+			t.Errorf("page %d is %s with -j 8, want %s", i, parallel[i].Path, sequential[i].Path)
+		} else if string(parallel[i].Content) != string(sequential[i].Content) {
+			t.Errorf("%s renders differently with -j 8", parallel[i].Path)
+		}
+	}
+}
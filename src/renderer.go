@@ -0,0 +1,192 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Page is everything a Renderer needs to write the documentation page of one header.
+type Page struct {
+	File *FileInfo
+	// Path of the page relative to the destination folder, with forward slashes
+	Path string
+	// FrontMatter is the executed front matter template of the config, without the '---' lines
+	FrontMatter string
+	// ExistingPath is the page written by a previous run, read by formats that keep hand written content.
+	// Empty when there is nothing to keep.
+	ExistingPath string
+	// Project holds every parsed header, for what the page shows of the other ones
+	Project *Project
+}
+
+// Renderer writes documentation pages in one output format.
+type Renderer interface {
+	// Extension of the page files, including the leading dot.
+	Extension() string
+	RenderPage(writer *bufio.Writer, page *Page) error
+	// RenderIndex writes the landing page listing every header and type of the project.
+	RenderIndex(writer *bufio.Writer, index *IndexPage) error
+}
+
+// sectionRenderer is implemented by the built-in formats, which all share the page layout of renderSections.
+type sectionRenderer interface {
+	BeginPage(writer *bufio.Writer, page *Page)
+	FileSummary(writer *bufio.Writer, file *FileInfo, enums, structs, classes []DataInfo)
+	EnumHeader(writer *bufio.Writer, d *DataInfo)
+	EnumValues(writer *bufio.Writer, d *DataInfo)
+	TypeHeader(writer *bufio.Writer, d *DataInfo)
+	Parents(writer *bufio.Writer, d *DataInfo)
+	// Derived writes the classes of the project inheriting from the type, or implementing it for interfaces.
+	Derived(writer *bufio.Writer, derived derivedList)
+	// Badges writes the labels of notable UE specifiers, see Specifiers.Badges. Also called by Functions for each function.
+	Badges(writer *bufio.Writer, badges []string)
+	Description(writer *bufio.Writer, d *DataInfo)
+	// Example writes C++ code from the overrides, or nothing when it is empty. Also called by Functions for each function.
+	Example(writer *bufio.Writer, example string)
+	// Diagram writes a Mermaid diagram, or nothing when it is empty.
+	Diagram(writer *bufio.Writer, mermaid string)
+	Properties(writer *bufio.Writer, d *DataInfo)
+	Functions(writer *bufio.Writer, d *DataInfo)
+	EndPage(writer *bufio.Writer, page *Page)
+}
+
+// Renderers by -format / output.format name. MDX pages are written by the built-in templates.
+var renderers = map[string]func() (Renderer, error){
+	"mdx":  func() (Renderer, error) { return newTemplateRenderer("") },
+	"md":   func() (Renderer, error) { return &markdownRenderer{}, nil },
+	"html": func() (Renderer, error) { return &htmlRenderer{}, nil },
+	"adoc": func() (Renderer, error) { return &asciidocRenderer{}, nil },
+	"rst":  func() (Renderer, error) { return &rstRenderer{}, nil },
+}
+
+func rendererNames() []string {
+	var names []string
+	for name := range renderers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func newRenderer(format string) (Renderer, error) {
+	create, ok := renderers[format]
+	if !ok {
+		return nil, fmt.Errorf("unknown format %q, expected one of %s", format, strings.Join(rendererNames(), ", "))
+	}
+	return create()
+}
+
+// renderSections writes the page layout shared by the built-in formats:
+// file summary, every enum, then the documented structs and classes.
+func renderSections(r sectionRenderer, writer *bufio.Writer, page *Page) {
+	r.BeginPage(writer, page)
+
+	enums, structs, classes := page.File.SplitTypes()
+	r.FileSummary(writer, page.File, enums, structs, classes)
+
+	for _, e := range enums {
+		r.EnumHeader(writer, &e)
+		r.Description(writer, &e)
+		r.Example(writer, e.Example)
+		r.EnumValues(writer, &e)
+	}
+
+	for _, types := range [][]DataInfo{structs, classes} {
+		for _, d := range types {
+			if d.HasDocumentation() {
+				r.TypeHeader(writer, &d)
+				r.Parents(writer, &d)
+				r.Derived(writer, page.Project.Derived(&d))
+				r.Badges(writer, d.Specifiers.Badges())
+				r.Description(writer, &d)
+				r.Example(writer, d.Example)
+				r.Diagram(writer, page.Project.TypeMermaid(&d, page.Path))
+				r.Properties(writer, &d)
+				r.Functions(writer, &d)
+			}
+		}
+	}
+
+	r.EndPage(writer, page)
+}
+
+// renderPage renders the page of fileInfo, one of the files of project, into memory.
+// existingPath is the page left by a previous run, or "".
+func renderPage(renderer Renderer, fileInfo *FileInfo, existingPath string, project *Project) ([]byte, error) {
+	frontMatter, err := project.Config.FrontMatter(fileInfo)
+	if err != nil {
+		return nil, fmt.Errorf("executing front matter template for %s: %w", fileInfo.Path, err)
+	}
+
+	page := &Page{
+		File:         fileInfo,
+		Path:         project.PagePath(fileInfo),
+		FrontMatter:  frontMatter,
+		ExistingPath: existingPath,
+		Project:      project,
+	}
+
+	var buffer bytes.Buffer
+	writer := bufio.NewWriter(&buffer)
+	if err := renderer.RenderPage(writer, page); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", fileInfo.Path, err)
+	}
+	writer.Flush()
+	return buffer.Bytes(), nil
+}
+
+// renderIndex renders the landing page of project at indexPath into memory.
+func renderIndex(renderer Renderer, project *Project, indexPath string) ([]byte, error) {
+	var buffer bytes.Buffer
+	writer := bufio.NewWriter(&buffer)
+	if err := renderer.RenderIndex(writer, project.Index(indexPath)); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", indexPath, err)
+	}
+	writer.Flush()
+	return buffer.Bytes(), nil
+}
+
+// typeMentions returns the known types mentioned by a set of declarations, see SymbolIndex.Mentions.
+func (p *Page) typeMentions(declarations []string, owner string) []Symbol {
+	return p.Project.Symbols.Mentions(strings.Join(declarations, "\n"), owner)
+}
+
+// anchor returns the id of the heading documenting a type, see slugify.
+func anchor(name string) string {
+	return slugify(name)
+}
+
+// typeName returns the name d is documented under, see SymbolIndex.Name.
+func (p *Page) typeName(d *DataInfo) string {
+	return p.Project.Symbols.Name(d)
+}
+
+// typeAnchor returns the id of the heading documenting d, also the name of its generated region.
+func (p *Page) typeAnchor(d *DataInfo) string {
+	return anchor(p.typeName(d))
+}
+
+// enumValueName returns the enumerator declaration without its trailing comma.
+func enumValueName(prop *PropertyInfo) string {
+	return strings.TrimRight(prop.Declaration, ",")
+}
+
+// enumValueDescription returns the documentation of an enumerator on one line, or its UMETA DisplayName, or its name
+// when undocumented.
+func enumValueDescription(prop *PropertyInfo) string {
+	documentation := prop.Documentation()
+	if len(documentation) == 0 {
+		if displayName := prop.Specifiers.DisplayName(); displayName != "" {
+			return displayName
+		}
+		return enumValueName(prop)
+	}
+	comment := []string{}
+	for _, comm := range documentation {
+		comment = append(comment, cleanComment(comm))
+	}
+	return strings.Join(comment, ", ")
+}
@@ -0,0 +1,358 @@
+package main
+
+import (
+	"bufio"
+	"path"
+	"strings"
+)
+
+// rstRenderer writes reStructuredText pages for Sphinx. Types get a label so other pages can :ref: them.
+type rstRenderer struct {
+	// page being rendered, set on a copy of the renderer by RenderPage
+	page *Page
+}
+
+func (r *rstRenderer) Extension() string {
+	return ".rst"
+}
+
+func (r *rstRenderer) RenderPage(writer *bufio.Writer, page *Page) error {
+	c := *r
+	c.page = page
+	renderSections(&c, writer, page)
+	return nil
+}
+
+// RenderIndex writes the landing page with a hidden toctree of every page, which Sphinx builds the navigation from.
+func (r *rstRenderer) RenderIndex(writer *bufio.Writer, index *IndexPage) error {
+	line := strings.Repeat("=", len(index.Title))
+	writer.WriteString(line + "\n" + index.Title + "\n" + line + "\n\n")
+
+	if len(index.Folders) > 0 {
+		writer.WriteString(".. toctree::\n   :hidden:\n\n")
+		for _, folder := range index.Folders {
+			for _, file := range folder.Files {
+				writer.WriteString("   " + strings.TrimSuffix(file.Href, path.Ext(file.Href)) + "\n")
+			}
+		}
+		writer.WriteString("\n")
+	}
+
+	for _, list := range []struct {
+		title   string
+		entries []IndexEntry
+	}{{"Classes", index.Classes}, {"Structs", index.Structs}, {"Enums", index.Enums}} {
+		if len(list.entries) == 0 {
+			continue
+		}
+		r.heading(writer, list.title, "-")
+		writer.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+		writer.WriteString("   * - Name\n     - File\n     - Summary\n")
+		for _, entry := range list.entries {
+			writer.WriteString("   * - :ref:`" + entry.Name + " <" + anchor(entry.Name) + ">`\n")
+			writer.WriteString("     - ``" + entry.File + "``\n")
+			writer.WriteString(strings.TrimRight("     - "+entry.Summary, " ") + "\n")
+		}
+		writer.WriteString("\n")
+	}
+
+	if len(index.Folders) > 0 {
+		r.heading(writer, "Files", "-")
+	}
+	for _, folder := range index.Folders {
+		r.heading(writer, "``"+folder.Name+"``", "~")
+		writer.WriteString(".. list-table::\n   :header-rows: 1\n\n")
+		writer.WriteString("   * - File\n     - Summary\n")
+		for _, file := range folder.Files {
+			writer.WriteString("   * - :doc:`" + file.Name + " <" + strings.TrimSuffix(file.Href, path.Ext(file.Href)) + ">`\n")
+			writer.WriteString(strings.TrimRight("     - "+file.Summary, " ") + "\n")
+		}
+		writer.WriteString("\n")
+	}
+	return nil
+}
+
+// typeLink returns a reference to the label of the type name, or name as literal when it is unknown.
+// Labels are global in Sphinx, so the page of the type does not matter.
+func (r *rstRenderer) typeLink(name string) string {
+	if symbol, ok := r.page.Project.Symbols.Lookup(name); ok {
+		return ":ref:`" + name + " <" + symbol.Anchor + ">`"
+	}
+	return "``" + name + "``"
+}
+
+// typeMentions writes references to the known types used by declarations, which code blocks cannot link themselves.
+func (r *rstRenderer) typeMentions(writer *bufio.Writer, declarations []string, owner string) {
+	symbols := r.page.typeMentions(declarations, owner)
+	if len(symbols) == 0 {
+		return
+	}
+	var links []string
+	for _, symbol := range symbols {
+		links = append(links, r.typeLink(symbol.Name))
+	}
+	writer.WriteString("**Types:** " + strings.Join(links, ", ") + "\n\n")
+}
+
+// heading writes title underlined with marker, Sphinx infers the level from the order markers appear in.
+func (r *rstRenderer) heading(writer *bufio.Writer, title string, marker string) {
+	writer.WriteString(title + "\n" + strings.Repeat(marker, len(title)) + "\n\n")
+}
+
+func (r *rstRenderer) BeginPage(writer *bufio.Writer, page *Page) {
+	line := strings.Repeat("=", len(page.File.Name))
+	writer.WriteString(line + "\n" + page.File.Name + "\n" + line + "\n\n")
+	r.heading(writer, "File Info", "-")
+}
+
+func (r *rstRenderer) EndPage(writer *bufio.Writer, page *Page) {
+}
+
+func (r *rstRenderer) FileSummary(writer *bufio.Writer, f *FileInfo, enums, structs, classes []DataInfo) {
+	writer.WriteString("**FileName:** ``" + f.Name + "``\n\n")
+	r.typeList(writer, "Enum List", enums, false)
+	r.typeList(writer, "Struct List", structs, true)
+	r.typeList(writer, "Class List", classes, true)
+	writer.WriteString("\n")
+}
+
+func (r *rstRenderer) typeList(writer *bufio.Writer, label string, types []DataInfo, documentedOnly bool) {
+	var links []string
+	for _, d := range types {
+		if !documentedOnly || d.HasDocumentation() {
+			links = append(links, ":ref:`"+r.page.typeName(&d)+" <"+r.page.typeAnchor(&d)+">`")
+		}
+	}
+	if len(links) > 0 {
+		writer.WriteString("- **" + label + ":** " + strings.Join(links, " | ") + "\n")
+	}
+}
+
+func (r *rstRenderer) TypeHeader(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString(".. _" + r.page.typeAnchor(d) + ":\n\n")
+	r.heading(writer, "``"+r.page.typeName(d)+"``", "-")
+}
+
+func (r *rstRenderer) EnumHeader(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString(".. _" + r.page.typeAnchor(d) + ":\n\n")
+	r.heading(writer, "``"+r.page.typeName(d)+"``", "~")
+}
+
+func (r *rstRenderer) Parents(writer *bufio.Writer, d *DataInfo) {
+	if len(d.Parents) == 0 {
+		return
+	}
+	var parents []string
+	for _, parent := range r.page.Project.Parents(d) {
+		parents = append(parents, r.typeLink(parent))
+	}
+	writer.WriteString("**Parent Classes:** " + strings.Join(parents, ", ") + "\n\n")
+}
+
+func (r *rstRenderer) Derived(writer *bufio.Writer, derived derivedList) {
+	if len(derived.Names) == 0 {
+		return
+	}
+	var links []string
+	for _, name := range derived.Names {
+		links = append(links, r.typeLink(name))
+	}
+	writer.WriteString("**" + derived.Title + ":** " + strings.Join(links, ", ") + "\n\n")
+}
+
+func (r *rstRenderer) Badges(writer *bufio.Writer, badges []string) {
+	if len(badges) > 0 {
+		writer.WriteString("**Specifiers:** " + strings.Join(badges, ", ") + "\n\n")
+	}
+}
+
+func (r *rstRenderer) Description(writer *bufio.Writer, d *DataInfo) {
+	doc := d.Doc()
+	if len(doc.Text) > 0 {
+		writer.WriteString(r.lineBlock(doc.Text, "") + "\n")
+	}
+	r.docTags(writer, &doc)
+}
+
+// lineBlock writes the lines of a comment as a line block, which keeps the line breaks of the source.
+func (r *rstRenderer) lineBlock(text []string, indent string) string {
+	var builder strings.Builder
+	for _, line := range text {
+		builder.WriteString(indent + "| " + line + "\n")
+	}
+	return builder.String()
+}
+
+// literal returns text as an inline literal, or "" when it is empty.
+func (r *rstRenderer) literal(text string) string {
+	if text == "" {
+		return ""
+	}
+	return "``" + text + "``"
+}
+
+// docTags writes the tags of a doc comment: the parameters table, the returned value and the see also references,
+// then the deprecation, notes and warnings as admonitions.
+func (r *rstRenderer) docTags(writer *bufio.Writer, doc *DocComment) {
+	if len(doc.Params) > 0 {
+		writer.WriteString("**Parameters:**\n\n")
+		writer.WriteString(".. list-table::\n")
+		writer.WriteString("   :header-rows: 1\n\n")
+		writer.WriteString("   * - Name\n")
+		writer.WriteString("     - Type\n")
+		writer.WriteString("     - Default\n")
+		writer.WriteString("     - Description\n")
+		for _, param := range doc.Params {
+			writer.WriteString("   * - " + r.literal(param.Name) + "\n")
+			writer.WriteString("     - " + r.literal(param.Type) + "\n")
+			writer.WriteString("     - " + r.literal(param.Default) + "\n")
+			writer.WriteString("     - " + param.Description + "\n")
+		}
+		writer.WriteString("\n")
+	}
+	if doc.Returns != "" {
+		writer.WriteString("**Returns:** " + doc.Returns + "\n\n")
+	}
+	if len(doc.See) > 0 {
+		var links []string
+		for _, ref := range doc.See {
+			link := "``" + ref + "``"
+			if symbol, ok := r.page.Project.Symbols.LookupReference(ref); ok {
+				link = ":ref:`" + ref + " <" + symbol.Anchor + ">`"
+			}
+			links = append(links, link)
+		}
+		writer.WriteString("**See also:** " + strings.Join(links, ", ") + "\n\n")
+	}
+	if doc.Deprecated {
+		writer.WriteString(".. admonition:: Deprecated\n   :class: danger\n\n   " + doc.DeprecationNotice() + "\n\n")
+	}
+	for _, note := range doc.Notes {
+		writer.WriteString(".. note::\n\n   " + note + "\n\n")
+	}
+	for _, warning := range doc.Warnings {
+		writer.WriteString(".. warning::\n\n   " + warning + "\n\n")
+	}
+}
+
+func (r *rstRenderer) codeBlock(writer *bufio.Writer, code string) {
+	writer.WriteString(".. code-block:: cpp\n\n")
+	for _, line := range strings.Split(strings.TrimRight(code, "\n"), "\n") {
+		if line == "" {
+			writer.WriteString("\n")
+		} else {
+			writer.WriteString("   " + line + "\n")
+		}
+	}
+	writer.WriteString("\n")
+}
+
+// Section markers below the "~" of the Properties and Functions headings, one per category depth.
+var rstCategoryMarkers = []string{"~", "^", "\"", "'", "`", "."}
+
+// groupHeading writes the heading of a category or function depth levels below the Properties and Functions
+// headings, or label in bold past the deepest marker.
+func (r *rstRenderer) groupHeading(writer *bufio.Writer, title string, label string, depth int) {
+	if depth >= len(rstCategoryMarkers) {
+		writer.WriteString("**" + label + "**\n\n")
+		return
+	}
+	r.heading(writer, title, rstCategoryMarkers[depth])
+}
+
+func (r *rstRenderer) Example(writer *bufio.Writer, example string) {
+	if example != "" {
+		writer.WriteString("**Example:**\n\n")
+		r.codeBlock(writer, example)
+	}
+}
+
+// Diagram writes a directive for the sphinxcontrib-mermaid extension.
+func (r *rstRenderer) Diagram(writer *bufio.Writer, mermaid string) {
+	if mermaid == "" {
+		return
+	}
+	writer.WriteString(".. mermaid::\n\n")
+	for _, line := range strings.Split(strings.TrimRight(mermaid, "\n"), "\n") {
+		writer.WriteString("   " + line + "\n")
+	}
+	writer.WriteString("\n")
+}
+
+func (r *rstRenderer) Properties(writer *bufio.Writer, d *DataInfo) {
+	if !d.HasDocumentedProperties() {
+		return
+	}
+
+	r.heading(writer, "Properties", "~")
+	r.propertyGroup(writer, propertyGroups(d))
+}
+
+// propertyGroup writes the properties of a Category in one code block, then its sub categories as sub sections.
+func (r *rstRenderer) propertyGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		r.groupHeading(writer, group.Name, group.Name, group.Depth)
+	}
+
+	if len(group.Properties) > 0 {
+		var code strings.Builder
+		for _, prop := range group.Properties {
+			for _, comm := range prop.Documentation() {
+				code.WriteString("// " + cleanComment(comm) + "\n")
+			}
+			if prop.Macro != "" {
+				code.WriteString(prop.Macro + "\n")
+			}
+			code.WriteString(prop.Declaration + "\n\n")
+		}
+		r.codeBlock(writer, code.String())
+		r.typeMentions(writer, group.Declarations(), group.Owner)
+	}
+
+	for _, sub := range group.Groups {
+		r.propertyGroup(writer, sub)
+	}
+}
+
+func (r *rstRenderer) Functions(writer *bufio.Writer, d *DataInfo) {
+	if !d.HasDocumentedFunctions() {
+		return
+	}
+
+	r.heading(writer, "Functions", "~")
+	r.functionGroup(writer, functionGroups(d))
+}
+
+// functionGroup writes the functions of a Category, then its sub categories as sub sections.
+func (r *rstRenderer) functionGroup(writer *bufio.Writer, group *memberGroup) {
+	if group.Depth > 0 {
+		r.groupHeading(writer, group.Name, group.Name, group.Depth)
+	}
+
+	for _, function := range group.Functions {
+		r.groupHeading(writer, "``"+function.Name+"``", function.Name, group.Depth+1)
+		r.Badges(writer, function.Specifiers.Badges())
+		doc := function.Doc()
+		writer.WriteString(r.lineBlock(doc.Text, "   ") + "\n")
+		r.codeBlock(writer, function.Declaration)
+		r.typeMentions(writer, []string{function.Declaration}, group.Owner)
+		r.docTags(writer, &doc)
+		r.Example(writer, function.Example)
+	}
+
+	for _, sub := range group.Groups {
+		r.functionGroup(writer, sub)
+	}
+}
+
+func (r *rstRenderer) EnumValues(writer *bufio.Writer, d *DataInfo) {
+	writer.WriteString(".. list-table::\n")
+	writer.WriteString("   :header-rows: 1\n\n")
+	writer.WriteString("   * - Value\n")
+	writer.WriteString("     - Description\n")
+	for _, prop := range d.Properties {
+		writer.WriteString("   * - ``" + enumValueName(&prop) + "``\n")
+		writer.WriteString("     - " + enumValueDescription(&prop) + "\n")
+	}
+	writer.WriteString("\n")
+}
@@ -0,0 +1,386 @@
+package main
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Parameter is one parameter of a function signature.
+type Parameter struct {
+	// Type is the declaration without the name and default value, e.g. 'const TArray<int32>&'
+	Type string `json:"type"`
+	// Name is "" for unnamed parameters
+	Name    string `json:"name"`
+	Default string `json:"default,omitempty"`
+	IsConst bool   `json:"isConst"`
+	// IsReference is set by '&' and '&&'
+	IsReference bool `json:"isReference"`
+	IsPointer   bool `json:"isPointer"`
+}
+
+// Signature is a function declaration split into its parts.
+type Signature struct {
+	// ReturnType is "" for constructors, destructors and conversion operators
+	ReturnType string      `json:"returnType"`
+	Name       string      `json:"name"`
+	Parameters []Parameter `json:"parameters"`
+	// Specifiers written before the name
+	IsVirtual   bool `json:"isVirtual"`
+	IsStatic    bool `json:"isStatic"`
+	IsInline    bool `json:"isInline"`
+	IsExplicit  bool `json:"isExplicit"`
+	IsConstexpr bool `json:"isConstexpr"`
+	// IsForceInline is set by FORCEINLINE and FORCEINLINE_DEBUGGABLE
+	IsForceInline bool `json:"isForceInline"`
+	// IsDeprecated is set by UE_DEPRECATED, DeprecationMessage is its message
+	IsDeprecated       bool   `json:"isDeprecated"`
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// Qualifiers written after the parameters
+	IsConst    bool `json:"isConst"`
+	IsOverride bool `json:"isOverride"`
+	IsFinal    bool `json:"isFinal"`
+	IsNoexcept bool `json:"isNoexcept"`
+	// IsPureVirtual is set by '= 0' and PURE_VIRTUAL
+	IsPureVirtual bool `json:"isPureVirtual"`
+	IsDefault     bool `json:"isDefault"`
+	IsDeleted     bool `json:"isDeleted"`
+}
+
+// Builtin type names, which name no parameter when they come last, as in 'unsigned int'.
+var builtinTypeNames = []string{"void", "bool", "char", "short", "int", "long", "float", "double", "signed", "unsigned", "const", "volatile", "auto"}
+
+// parseSignature splits a function declaration into its return type, name, parameters, specifiers and qualifiers.
+// The parameter list is the first parenthesis outside template arguments and declaration macros, as for the parser.
+func parseSignature(declaration string) Signature {
+	var tokens []Token
+	for _, tok := range tokenize(declaration) {
+		if tok.Kind != TokenComment && tok.Kind != TokenEOF {
+			tokens = append(tokens, tok)
+		}
+	}
+
+	open := -1
+	depth := 0
+	angles := 0
+	// Parentheses of pointer declarators, as in 'void (*GetCallback(int Index))(float)', are not parameter lists
+	declarators := 0
+	for i := 0; i < len(tokens) && open < 0; i++ {
+		tok := tokens[i]
+		if tok.Kind != TokenPunct {
+			continue
+		}
+		switch tok.Text {
+		case "<":
+			if i > 0 && tokens[i-1].Kind == TokenIdent && tokens[i-1].Text != "operator" {
+				angles++
+			}
+		case ">":
+			if angles > 0 {
+				angles--
+			}
+		case "(":
+			if depth == 0 && opensDeclarator(tokens, i) {
+				declarators++
+				continue
+			}
+			if depth == 0 && angles == 0 && (i == 0 || !containsString(declarationMacros, tokens[i-1].Text)) {
+				open = i
+			}
+			depth++
+		case "[":
+			depth++
+		case ")", "]":
+			if depth == 0 && declarators > 0 {
+				declarators--
+				continue
+			}
+			depth--
+		}
+	}
+	if open < 1 {
+		return Signature{Name: strings.TrimSuffix(declaration, ";")}
+	}
+	// The call operator is named by its first pair of parentheses
+	if tokens[open-1].Text == "operator" && open+2 < len(tokens) && tokens[open+1].Text == ")" && tokens[open+2].Text == "(" {
+		open += 2
+	}
+
+	var sig Signature
+	nameStart := signatureName(tokens, open, &sig)
+	// The scope of an out of class definition is not part of the name nor of the return type
+	for nameStart >= 2 && tokens[nameStart-1].Text == "::" && tokens[nameStart-2].Kind == TokenIdent {
+		nameStart -= 2
+	}
+
+	close := matchingClose(tokens, open)
+	sig.ReturnType = signaturePrefix(declaration, tokens[:nameStart], &sig)
+	sig.Parameters = signatureParameters(declaration, tokens[open+1:close])
+
+	// A function returning a pointer to function ends its return type after its parameters: 'void (*)(float)'
+	end := close
+	for ; declarators > 0 && end+1 < len(tokens) && tokens[end+1].Text == ")"; declarators-- {
+		end++
+		sig.ReturnType += ")"
+	}
+	if end > close && end+1 < len(tokens) && tokens[end+1].Text == "(" {
+		group := matchingClose(tokens, end+1)
+		sig.ReturnType += declaration[tokens[end+1].Start:tokens[group].End]
+		end = group
+	}
+	signatureSuffix(declaration, tokens[min(end+1, len(tokens)):], &sig)
+	return sig
+}
+
+// signatureName sets the name of sig from the tokens before the parameter list opening at open, and returns the index
+// of its first token.
+func signatureName(tokens []Token, open int, sig *Signature) int {
+	nameIndex := open - 1
+	for i := 0; i <= nameIndex; i++ {
+		if tokens[i].Text != "operator" {
+			continue
+		}
+		if i == nameIndex {
+			sig.Name = "operator()"
+			return i
+		}
+		name := "operator"
+		for _, tok := range tokens[i+1 : nameIndex+1] {
+			if tok.Kind == TokenIdent {
+				name += " "
+			}
+			name += tok.Text
+		}
+		if tokens[nameIndex].Text == ")" && tokens[i+1].Text == "(" {
+			name = "operator()"
+		}
+		sig.Name = name
+		return i
+	}
+
+	sig.Name = tokens[nameIndex].Text
+	if nameIndex > 0 && tokens[nameIndex-1].Text == "~" {
+		sig.Name = "~" + sig.Name
+		return nameIndex - 1
+	}
+	return nameIndex
+}
+
+// signaturePrefix sets the specifiers of sig found in tokens, which come before the name, and returns the source of
+// the other tokens: the return type.
+func signaturePrefix(declaration string, tokens []Token, sig *Signature) string {
+	var parts []string
+	typeStart := -1
+	flush := func(end int) {
+		if typeStart >= 0 {
+			parts = append(parts, declaration[tokens[typeStart].Start:tokens[end-1].End])
+			typeStart = -1
+		}
+	}
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		skipped := true
+		switch {
+		case tok.Text == "virtual":
+			sig.IsVirtual = true
+		case tok.Text == "static":
+			sig.IsStatic = true
+		case tok.Text == "inline":
+			sig.IsInline = true
+		case tok.Text == "explicit":
+			sig.IsExplicit = true
+		case tok.Text == "constexpr" || tok.Text == "CONSTEXPR":
+			sig.IsConstexpr = true
+		case tok.Text == "FORCEINLINE" || tok.Text == "FORCEINLINE_DEBUGGABLE":
+			sig.IsForceInline = true
+		case tok.Text == "FORCENOINLINE" || tok.Text == "UE_NODISCARD" || tok.Text == "friend" || isApiMacro(tok.Text):
+		case tok.Text == "UE_DEPRECATED" || tok.Text == "UE_DEPRECATED_FORGAME":
+			sig.IsDeprecated = true
+			if i+1 < len(tokens) && tokens[i+1].Text == "(" {
+				end := matchingClose(tokens, i+1)
+				for _, arg := range tokens[i+2 : end] {
+					if arg.Kind == TokenString {
+						sig.DeprecationMessage = unquoteString(arg.Text)
+					}
+				}
+				flush(i)
+				i = end
+				continue
+			}
+		case tok.Text == "[" && i+1 < len(tokens) && tokens[i+1].Text == "[":
+			// Attribute such as [[nodiscard]]
+			flush(i)
+			for i < len(tokens) && !(tokens[i].Text == "]" && i > 0 && tokens[i-1].Text == "]") {
+				i++
+			}
+			continue
+		default:
+			skipped = false
+		}
+		if skipped {
+			flush(i)
+		} else if typeStart < 0 {
+			typeStart = i
+		}
+	}
+	flush(len(tokens))
+	return strings.Join(parts, " ")
+}
+
+// signatureParameters splits the tokens between the parentheses of a parameter list into parameters.
+func signatureParameters(declaration string, tokens []Token) []Parameter {
+	var parameters []Parameter
+	start := 0
+	depth := 0
+	for i := 0; i <= len(tokens); i++ {
+		if i < len(tokens) {
+			switch tokens[i].Text {
+			case "(", "<", "[", "{":
+				depth++
+				continue
+			case ")", ">", "]", "}":
+				depth--
+				continue
+			case ",":
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		param := tokens[start:i]
+		start = i + 1
+		// '()' and '(void)' declare no parameter
+		if len(param) == 0 || (len(param) == 1 && param[0].Text == "void") {
+			continue
+		}
+		parameters = append(parameters, signatureParameter(declaration, param))
+	}
+	return parameters
+}
+
+// signatureParameter parses the tokens of one parameter.
+func signatureParameter(declaration string, tokens []Token) Parameter {
+	var parameter Parameter
+	source := func(from, to int) string {
+		if from >= to {
+			return ""
+		}
+		return declaration[tokens[from].Start:tokens[to-1].End]
+	}
+
+	// The default value follows the first '=' outside brackets, an array size the first '['
+	end := len(tokens)
+	suffix := len(tokens)
+	depth := 0
+	for i, tok := range tokens {
+		switch tok.Text {
+		case "(", "<", "{":
+			depth++
+		case ")", ">", "}":
+			depth--
+		case "=":
+			if depth == 0 && end == len(tokens) {
+				end = i
+				parameter.Default = source(i+1, len(tokens))
+			}
+		case "[":
+			if depth == 0 && suffix == len(tokens) {
+				suffix = i
+			}
+		}
+		if depth == 0 {
+			switch tok.Text {
+			case "const":
+				parameter.IsConst = true
+			case "&", "&&":
+				parameter.IsReference = true
+			case "*":
+				parameter.IsPointer = true
+			}
+		}
+	}
+	suffix = min(suffix, end)
+
+	nameIndex := -1
+	if suffix >= 2 {
+		last := tokens[suffix-1]
+		if last.Kind == TokenIdent && !slices.Contains(builtinTypeNames, last.Text) && tokens[suffix-2].Text != "::" {
+			nameIndex = suffix - 1
+		}
+	}
+	if nameIndex < 0 {
+		parameter.Type = source(0, end)
+		return parameter
+	}
+	parameter.Name = tokens[nameIndex].Text
+	parameter.Type = strings.TrimSpace(source(0, nameIndex) + source(nameIndex+1, end))
+	return parameter
+}
+
+// signatureSuffix sets the qualifiers of sig found in tokens, which follow the parameter list.
+func signatureSuffix(declaration string, tokens []Token, sig *Signature) {
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		switch tok.Text {
+		case "const":
+			sig.IsConst = true
+		case "override":
+			sig.IsOverride = true
+		case "final":
+			sig.IsFinal = true
+		case "noexcept":
+			sig.IsNoexcept = true
+		case "PURE_VIRTUAL":
+			sig.IsPureVirtual = true
+		case "0":
+			if i > 0 && tokens[i-1].Text == "=" {
+				sig.IsPureVirtual = true
+			}
+		case "default":
+			sig.IsDefault = true
+		case "delete":
+			sig.IsDeleted = true
+		case "->":
+			// Trailing return type, up to the qualifiers
+			end := i + 1
+			for end < len(tokens) && !containsString([]string{"override", "final", "=", ";", "{"}, tokens[end].Text) {
+				end++
+			}
+			if end > i+1 {
+				sig.ReturnType = declaration[tokens[i+1].Start:tokens[end-1].End]
+			}
+			i = end - 1
+		case "(":
+			i = matchingClose(tokens, i)
+		case "{", ";":
+			return
+		}
+	}
+}
+
+// matchingClose returns the index of the token closing the bracket opening at tokens[open], or the last index.
+func matchingClose(tokens []Token, open int) int {
+	closing := map[string]string{"(": ")", "[": "]", "{": "}", "<": ">"}[tokens[open].Text]
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch tokens[i].Text {
+		case tokens[open].Text:
+			depth++
+		case closing:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return len(tokens) - 1
+}
+
+func unquoteString(literal string) string {
+	if text, err := strconv.Unquote(literal); err == nil {
+		return text
+	}
+	return strings.Trim(literal, "\"")
+}
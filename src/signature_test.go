@@ -0,0 +1,88 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSignature(t *testing.T) {
+	for _, test := range []struct {
+		declaration string
+		want        Signature
+	}{
+		{
+			"virtual void Enter(UObject* Context = nullptr, const TArray<int32>& Values = {}) const override;",
+			Signature{ReturnType: "void", Name: "Enter", Parameters: []Parameter{
+				{Type: "UObject*", Name: "Context", Default: "nullptr", IsPointer: true},
+				{Type: "const TArray<int32>&", Name: "Values", Default: "{}", IsConst: true, IsReference: true},
+			}, IsVirtual: true, IsConst: true, IsOverride: true},
+		},
+		{
+			"static UOut* Make(TSubclassOf<UFooTask> Cls);",
+			Signature{ReturnType: "UOut*", Name: "Make", Parameters: []Parameter{{Type: "TSubclassOf<UFooTask>", Name: "Cls"}}, IsStatic: true},
+		},
+		{
+			"FORCEINLINE TMap<FName, TArray<int32>> GetMap() const noexcept { return Map; }",
+			Signature{ReturnType: "TMap<FName, TArray<int32>>", Name: "GetMap", IsForceInline: true, IsConst: true, IsNoexcept: true},
+		},
+		{
+			`UE_DEPRECATED(5.1, "Use Run instead") void Start(int32, float Scale = 1.f);`,
+			Signature{ReturnType: "void", Name: "Start", Parameters: []Parameter{
+				{Type: "int32"},
+				{Type: "float", Name: "Scale", Default: "1.f"},
+			}, IsDeprecated: true, DeprecationMessage: "Use Run instead"},
+		},
+		{
+			"explicit FFoo(int32 InValue);",
+			Signature{Name: "FFoo", Parameters: []Parameter{{Type: "int32", Name: "InValue"}}, IsExplicit: true},
+		},
+		{
+			"virtual ~FFoo() = default;",
+			Signature{Name: "~FFoo", IsVirtual: true, IsDefault: true},
+		},
+		{
+			"FFoo(const FFoo&) = delete;",
+			Signature{Name: "FFoo", Parameters: []Parameter{{Type: "const FFoo&", IsConst: true, IsReference: true}}, IsDeleted: true},
+		},
+		{
+			"operator bool() const;",
+			Signature{Name: "operator bool", IsConst: true},
+		},
+		{
+			"bool operator<(const FFoo& Other) const;",
+			Signature{ReturnType: "bool", Name: "operator<", Parameters: []Parameter{{Type: "const FFoo&", Name: "Other", IsConst: true, IsReference: true}}, IsConst: true},
+		},
+		{
+			"virtual void Tick(float Delta) PURE_VIRTUAL(UFoo::Tick, );",
+			Signature{ReturnType: "void", Name: "Tick", Parameters: []Parameter{{Type: "float", Name: "Delta"}}, IsVirtual: true, IsPureVirtual: true},
+		},
+		{
+			"void Move(FFoo&& Other, unsigned int, const AActor* /*Actor*/);",
+			Signature{ReturnType: "void", Name: "Move", Parameters: []Parameter{
+				{Type: "FFoo&&", Name: "Other", IsReference: true},
+				{Type: "unsigned int"},
+				{Type: "const AActor*", IsConst: true, IsPointer: true},
+			}},
+		},
+		{
+			"constexpr inline int32 Count() final;",
+			Signature{ReturnType: "int32", Name: "Count", IsInline: true, IsConstexpr: true, IsFinal: true},
+		},
+		{
+			"auto Find(int32 Id) -> FFoo*;",
+			Signature{ReturnType: "FFoo*", Name: "Find", Parameters: []Parameter{{Type: "int32", Name: "Id"}}},
+		},
+		{
+			"static void (*GetCallback(int Index))(float) noexcept;",
+			Signature{ReturnType: "void (*)(float)", Name: "GetCallback", Parameters: []Parameter{{Type: "int", Name: "Index"}}, IsStatic: true, IsNoexcept: true},
+		},
+		{
+			"void (*GetCallback(int Index))(float);",
+			Signature{ReturnType: "void (*)(float)", Name: "GetCallback", Parameters: []Parameter{{Type: "int", Name: "Index"}}},
+		},
+	} {
+		if got := parseSignature(test.declaration); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseSignature(%q)\n got %+v\nwant %+v", test.declaration, got, test.want)
+		}
+	}
+}
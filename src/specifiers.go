@@ -0,0 +1,196 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Specifier is one argument of a reflection macro, e.g. 'BlueprintCallable' or 'Category="Task"'. Value is empty
+// for flags, and has its quotes removed for strings.
+type Specifier struct {
+	Name  string `json:"name"`
+	Value string `json:"value,omitempty"`
+}
+
+// Specifiers are the arguments of a UCLASS, USTRUCT, UENUM, UPROPERTY or UFUNCTION macro, in source order.
+// The entries of 'meta=(...)' are kept apart in Meta. Names are compared ignoring case, like UnrealHeaderTool does.
+type Specifiers struct {
+	List []Specifier `json:"list,omitempty"`
+	Meta []Specifier `json:"meta,omitempty"`
+}
+
+// Specifiers rendered as badges, in display order. The label is the name split on its capitals.
+var badgeSpecifiers = []string{
+	// Types
+	"Abstract", "Blueprintable", "BlueprintType", "NotBlueprintable", "EditInlineNew", "Deprecated",
+	// Properties
+	"EditAnywhere", "EditDefaultsOnly", "EditInstanceOnly", "VisibleAnywhere", "VisibleDefaultsOnly", "VisibleInstanceOnly",
+	"BlueprintReadOnly", "BlueprintReadWrite", "BlueprintAssignable", "Replicated", "ReplicatedUsing", "Transient", "Config", "SaveGame",
+	// Functions
+	"BlueprintCallable", "BlueprintPure", "BlueprintImplementableEvent", "BlueprintNativeEvent", "BlueprintAuthorityOnly",
+	"CallInEditor", "Exec", "Server", "Client", "NetMulticast",
+}
+
+// parseSpecifiers parses the arguments of a reflection macro call such as 'UPROPERTY(EditAnywhere, meta=(ClampMin=0))'.
+// It never fails, arguments it does not understand are kept as flags with their source text as name.
+func parseSpecifiers(macro string) Specifiers {
+	var specifiers Specifiers
+
+	tokens := tokenize(macro)
+	open := -1
+	for i, tok := range tokens {
+		if tok.Text == "(" {
+			open = i
+			break
+		}
+	}
+	if open < 0 {
+		return specifiers
+	}
+
+	for _, arg := range splitArguments(macro, tokens[open+1:]) {
+		if strings.EqualFold(arg.Name, "meta") && strings.HasPrefix(arg.Value, "(") {
+			inner := tokenize(arg.Value)
+			if len(inner) > 0 {
+				specifiers.Meta = append(specifiers.Meta, splitArguments(arg.Value, inner[1:])...)
+			}
+			continue
+		}
+		specifiers.List = append(specifiers.List, arg)
+	}
+	return specifiers
+}
+
+// splitArguments splits tokens, which follow an opening parenthesis of source, on top level commas up to the matching ')'.
+// Comments are skipped.
+func splitArguments(source string, tokens []Token) []Specifier {
+	var args []Specifier
+
+	var code []Token
+	for _, tok := range tokens {
+		if tok.Kind != TokenComment {
+			code = append(code, tok)
+		}
+	}
+	tokens = code
+
+	depth := 0
+	start := 0
+	flush := func(end int) {
+		if end <= start {
+			return
+		}
+		arg := tokens[start:end]
+		if arg[0].Kind == TokenIdent && len(arg) > 2 && arg[1].Text == "=" {
+			args = append(args, Specifier{Name: arg[0].Text, Value: specifierValue(source, arg[2:])})
+			return
+		}
+		args = append(args, Specifier{Name: normalizeText(source[arg[0].Start:arg[len(arg)-1].End])})
+	}
+
+	for i, tok := range tokens {
+		switch tok.Text {
+		case "(":
+			depth++
+		case ")":
+			if depth == 0 {
+				flush(i)
+				return args
+			}
+			depth--
+		case ",":
+			if depth == 0 {
+				flush(i)
+				start = i + 1
+			}
+		}
+	}
+	flush(len(tokens))
+	return args
+}
+
+// specifierValue returns the source text of a value, without quotes when it is a single string literal.
+func specifierValue(source string, tokens []Token) string {
+	if len(tokens) == 1 && tokens[0].Kind == TokenString {
+		if value, err := strconv.Unquote(tokens[0].Text); err == nil {
+			return value
+		}
+		return strings.Trim(tokens[0].Text, `"`)
+	}
+	return normalizeText(source[tokens[0].Start:tokens[len(tokens)-1].End])
+}
+
+func findSpecifier(list []Specifier, name string) (Specifier, bool) {
+	for _, spec := range list {
+		if strings.EqualFold(spec.Name, name) {
+			return spec, true
+		}
+	}
+	return Specifier{}, false
+}
+
+// Has reports whether the specifier name is present, as a flag or with a value.
+func (s Specifiers) Has(name string) bool {
+	_, ok := findSpecifier(s.List, name)
+	return ok
+}
+
+// Value returns the value of the specifier name, or "".
+func (s Specifiers) Value(name string) string {
+	spec, _ := findSpecifier(s.List, name)
+	return spec.Value
+}
+
+// MetaValue returns the value of the meta entry name, or "".
+func (s Specifiers) MetaValue(name string) string {
+	spec, _ := findSpecifier(s.Meta, name)
+	return spec.Value
+}
+
+// Category returns the Category specifier, e.g. "FlowPilot|Conditions".
+func (s Specifiers) Category() string {
+	return s.Value("Category")
+}
+
+// ToolTip returns the ToolTip meta entry.
+func (s Specifiers) ToolTip() string {
+	return s.MetaValue("ToolTip")
+}
+
+// DisplayName returns the DisplayName meta entry.
+func (s Specifiers) DisplayName() string {
+	return s.MetaValue("DisplayName")
+}
+
+// Badges returns the labels of the notable specifiers, e.g. "Blueprint Callable".
+func (s Specifiers) Badges() []string {
+	var badges []string
+	for _, name := range badgeSpecifiers {
+		if s.Has(name) {
+			badges = append(badges, splitCamelCase(name))
+		}
+	}
+	return badges
+}
+
+// splitCamelCase inserts a space before every capital following a lower case letter: "BlueprintReadOnly" -> "Blueprint Read Only".
+func splitCamelCase(name string) string {
+	var builder strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[i-1]) {
+			builder.WriteRune(' ')
+		}
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// documentation returns the comments of a declaration, or its ToolTip meta when it has no comment.
+func documentation(comments []string, specifiers Specifiers) []string {
+	if len(comments) == 0 && specifiers.ToolTip() != "" {
+		return []string{specifiers.ToolTip()}
+	}
+	return comments
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSpecifiers(t *testing.T) {
+	for _, test := range []struct {
+		macro string
+		want  Specifiers
+	}{
+		{"UPROPERTY()", Specifiers{}},
+		{"GENERATED_BODY", Specifiers{}},
+		{
+			"UPROPERTY(EditAnywhere, BlueprintReadOnly, Category = \"Task|Speed\")",
+			Specifiers{List: []Specifier{{"EditAnywhere", ""}, {"BlueprintReadOnly", ""}, {"Category", "Task|Speed"}}},
+		},
+		{
+			"UFUNCTION(BlueprintCallable, meta = (DisplayName = \"Run Task\", ClampMin = 0, ClampMax = \"100\"))",
+			Specifiers{
+				List: []Specifier{{"BlueprintCallable", ""}},
+				Meta: []Specifier{{"DisplayName", "Run Task"}, {"ClampMin", "0"}, {"ClampMax", "100"}},
+			},
+		},
+		{
+			// Commas and parentheses inside quotes and nested parentheses do not split the arguments
+			"UPROPERTY(meta = (ToolTip = \"Speed (m/s), never negative\", EditCondition = \"(bA || bB), bC\", GetOptions = GetNames(Foo, Bar)), Transient)",
+			Specifiers{
+				List: []Specifier{{"Transient", ""}},
+				Meta: []Specifier{{"ToolTip", "Speed (m/s), never negative"}, {"EditCondition", "(bA || bB), bC"}, {"GetOptions", "GetNames(Foo, Bar)"}},
+			},
+		},
+		{
+			// Escaped quotes, flags inside meta and comments between the arguments
+			"UPROPERTY(Config, /* saved */ meta = (AllowPrivateAccess, ToolTip = \"The \\\"main\\\" one\"))",
+			Specifiers{
+				List: []Specifier{{"Config", ""}},
+				Meta: []Specifier{{"AllowPrivateAccess", ""}, {"ToolTip", "The \"main\" one"}},
+			},
+		},
+		{
+			"UFUNCTION(Server, Reliable, WithValidation,\n\tCategory = Net)",
+			Specifiers{List: []Specifier{{"Server", ""}, {"Reliable", ""}, {"WithValidation", ""}, {"Category", "Net"}}},
+		},
+	} {
+		if got := parseSpecifiers(test.macro); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("parseSpecifiers(%q)\n got %+v\nwant %+v", test.macro, got, test.want)
+		}
+	}
+}
+
+func TestSpecifiersLookup(t *testing.T) {
+	specifiers := parseSpecifiers(`UPROPERTY(editanywhere, blueprintreadwrite, category="Task", META=(tooltip="Speed", displayname="Task Speed"))`)
+	if !specifiers.Has("EditAnywhere") || specifiers.Has("VisibleAnywhere") {
+		t.Errorf("Has ignores case: got %+v", specifiers.List)
+	}
+	if got := specifiers.Category(); got != "Task" {
+		t.Errorf("Category() = %q, want %q", got, "Task")
+	}
+	if got := specifiers.ToolTip(); got != "Speed" {
+		t.Errorf("ToolTip() = %q, want %q", got, "Speed")
+	}
+	if got := specifiers.DisplayName(); got != "Task Speed" {
+		t.Errorf("DisplayName() = %q, want %q", got, "Task Speed")
+	}
+	if got, want := specifiers.Badges(), []string{"Edit Anywhere", "Blueprint Read Write"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Badges() = %q, want %q", got, want)
+	}
+}
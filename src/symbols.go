@@ -0,0 +1,172 @@
+package main
+
+import (
+	"path"
+	"strings"
+)
+
+// Symbol is a type documented on a generated page.
+type Symbol struct {
+	Name string
+	// Page is the path of the page documenting the type, relative to the destination folder, with forward slashes
+	Page   string
+	Anchor string
+}
+
+// SymbolIndex knows where every documented type of the project is, so pages can link to each other.
+type SymbolIndex struct {
+	symbols map[string]Symbol
+	// ambiguous are the scoped names shared by types of different namespaces
+	ambiguous map[string]bool
+}
+
+// buildSymbolIndex indexes the types that get a section on their page: every enum, and the documented structs and classes.
+// Types are indexed by their qualified name, then by their scoped name and their name. When two types have the same
+// name, the first one wins.
+func buildSymbolIndex(fileInfoList []FileInfo, cfg *Config) *SymbolIndex {
+	index := &SymbolIndex{symbols: map[string]Symbol{}, ambiguous: map[string]bool{}}
+	qualified := map[string]string{}
+	for i := range fileInfoList {
+		for _, data := range fileInfoList[i].Data {
+			if data.Name == "" {
+				continue
+			}
+			if name, exists := qualified[data.ScopedName()]; !exists {
+				qualified[data.ScopedName()] = data.QualifiedName()
+			} else if name != data.QualifiedName() {
+				index.ambiguous[data.ScopedName()] = true
+			}
+		}
+	}
+
+	// Every qualified name is indexed first, so no other type hides it
+	keys := []func(d *DataInfo) string{
+		(*DataInfo).QualifiedName,
+		(*DataInfo).ScopedName,
+		func(d *DataInfo) string { return d.Name },
+	}
+	for _, key := range keys {
+		for i := range fileInfoList {
+			fileInfo := &fileInfoList[i]
+			page := pageRelPath(fileInfo, cfg)
+			for j := range fileInfo.Data {
+				data := &fileInfo.Data[j]
+				if data.Name == "" || (!data.IsEnum && !data.HasDocumentation()) {
+					continue
+				}
+				if _, exists := index.symbols[key(data)]; !exists {
+					index.symbols[key(data)] = Symbol{Name: index.Name(data), Page: page, Anchor: anchor(index.Name(data))}
+				}
+			}
+		}
+	}
+	return index
+}
+
+// Name returns the name d is documented under: its scoped name, or its qualified name when types of other namespaces
+// have the same scoped name.
+func (s *SymbolIndex) Name(d *DataInfo) string {
+	if s != nil && s.ambiguous[d.ScopedName()] {
+		return d.QualifiedName()
+	}
+	return d.ScopedName()
+}
+
+// pageRelPath returns the page of fileInfo relative to the destination folder, with forward slashes.
+func pageRelPath(fileInfo *FileInfo, cfg *Config) string {
+	return cfg.PagePath(fileInfo.RelPath, cfg.Output.Extension)
+}
+
+// Lookup returns the symbol documenting name. Qualified names are looked up as written, then by their last part.
+func (s *SymbolIndex) Lookup(name string) (Symbol, bool) {
+	if s == nil {
+		return Symbol{}, false
+	}
+	if symbol, ok := s.symbols[name]; ok {
+		return symbol, true
+	}
+	if i := strings.LastIndex(name, "::"); i >= 0 {
+		name = name[i+2:]
+	}
+	symbol, ok := s.symbols[name]
+	return symbol, ok
+}
+
+// LookupReference returns the symbol of a @see reference: a type, or a member such as 'UFlowPilotTask::Enter()' or
+// 'UFlowPilotTask#Enter', documented in the section of its type.
+func (s *SymbolIndex) LookupReference(ref string) (Symbol, bool) {
+	ref = strings.TrimSuffix(strings.ReplaceAll(ref, "#", "::"), "()")
+	if symbol, ok := s.Lookup(ref); ok {
+		return symbol, true
+	}
+	if i := strings.LastIndex(ref, "::"); i >= 0 {
+		return s.Lookup(ref[:i])
+	}
+	return Symbol{}, false
+}
+
+// Href returns the link to symbol from the page fromPage: only the anchor on the same page, a relative path otherwise.
+func (s *SymbolIndex) Href(fromPage string, symbol Symbol) string {
+	if symbol.Page == fromPage {
+		return "#" + symbol.Anchor
+	}
+	return relativePath(path.Dir(fromPage), symbol.Page) + "#" + symbol.Anchor
+}
+
+// relativePath returns target relative to the folder from, both relative to the same root with forward slashes.
+func relativePath(from, target string) string {
+	fromParts := strings.Split(path.Clean(from), "/")
+	targetParts := strings.Split(path.Clean(target), "/")
+	if fromParts[0] == "." {
+		fromParts = nil
+	}
+
+	common := 0
+	for common < len(fromParts) && common < len(targetParts)-1 && fromParts[common] == targetParts[common] {
+		common++
+	}
+
+	var parts []string
+	for range fromParts[common:] {
+		parts = append(parts, "..")
+	}
+	return path.Join(append(parts, targetParts[common:]...)...)
+}
+
+// Mentions returns the known types named in a declaration, e.g. the 'UFlowPilotTask' of
+// 'TSubclassOf<UFlowPilotTask> TaskClass;', once each in order of appearance. The type exclude is left out.
+func (s *SymbolIndex) Mentions(declaration string, exclude string) []Symbol {
+	var symbols []Symbol
+	seen := map[string]bool{exclude: true}
+	for _, tok := range tokenize(declaration) {
+		if tok.Kind != TokenIdent || seen[tok.Text] {
+			continue
+		}
+		seen[tok.Text] = true
+		if symbol, ok := s.Lookup(tok.Text); ok {
+			symbols = append(symbols, symbol)
+		}
+	}
+	return symbols
+}
+
+// Linkify rebuilds a declaration with every known type replaced by link(name, symbol), and the text around
+// it passed through escape. Used by formats whose code blocks can hold links.
+func (s *SymbolIndex) Linkify(declaration string, escape func(string) string, link func(text string, symbol Symbol) string) string {
+	var builder strings.Builder
+	last := 0
+	for _, tok := range tokenize(declaration) {
+		if tok.Kind != TokenIdent {
+			continue
+		}
+		symbol, ok := s.Lookup(tok.Text)
+		if !ok {
+			continue
+		}
+		builder.WriteString(escape(declaration[last:tok.Start]))
+		builder.WriteString(link(tok.Text, symbol))
+		last = tok.End
+	}
+	builder.WriteString(escape(declaration[last:]))
+	return builder.String()
+}
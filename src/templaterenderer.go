@@ -0,0 +1,206 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates/mdx/*.tmpl
+var builtinTemplates embed.FS
+
+const builtinTemplateFolder = "templates/mdx"
+
+// templatePage is the data of the "page" template. The other templates get a DataInfo ("type", "enum", "description"),
+// a memberGroup ("properties", "functions"), a PropertyInfo ("property"), a FunctionInfo ("function"), a DocComment
+// ("doctags") or an IndexPage ("index").
+type templatePage struct {
+	File *FileInfo
+	// Executed front matter template of the config, without the '---' lines
+	FrontMatter string
+	Enums       []DataInfo
+	Structs     []DataInfo
+	Classes     []DataInfo
+	// Every parsed header, with .Files and .Symbols
+	Project *Project
+}
+
+var slugInvalidChars = regexp.MustCompile(`[^a-z0-9_-]+`)
+
+// slugify lowers text and replaces everything but letters, digits, '_' and '-' with '-'. Scope separators are dropped,
+// as in the heading ids of documentation sites: 'UFlowPilotTask::FState' -> 'uflowpilottaskfstate'.
+func slugify(text string) string {
+	text = strings.ReplaceAll(text, "::", "")
+	return strings.Trim(slugInvalidChars.ReplaceAllString(strings.ToLower(text), "-"), "-")
+}
+
+// isLast reports whether index is the last index of list, which must be a slice.
+func isLast(index int, list any) bool {
+	value := reflect.ValueOf(list)
+	return value.Kind() == reflect.Slice && index == value.Len()-1
+}
+
+var templateFuncs = template.FuncMap{
+	"slugify":         slugify,
+	"cleanComment":    cleanComment,
+	"join":            strings.Join,
+	"isLast":          isLast,
+	"enumValue":       func(prop PropertyInfo) string { return enumValueName(&prop) },
+	"enumDescription": func(prop PropertyInfo) string { return enumValueDescription(&prop) },
+	"propertyGroups":  propertyGroups,
+	"functionGroups":  functionGroups,
+	"markdownCell":    markdownCell,
+	"markdownCode":    markdownCodeCell,
+	"beginGenerated":  func(name string) string { return generatedMarker(true, "BEGIN", name) },
+	"endGenerated":    func(name string) string { return generatedMarker(true, "END", name) },
+	"heading":         func(base, depth int, text string) string { return markdownHeading(headingLevel(base, depth), text) },
+	// Replaced by pageFuncs for each page, they are declared here so templates using them parse
+	"typeName":     func(d *DataInfo) string { return d.ScopedName() },
+	"typeAnchor":   func(d *DataInfo) string { return slugify(d.ScopedName()) },
+	"typeHref":     func(name string) string { return "" },
+	"typeLink":     func(name string) string { return "" },
+	"seeLink":      func(ref string) string { return "" },
+	"typeMentions": func(declarations any, owner string) []Symbol { return nil },
+	"parents":      func(d *DataInfo) []string { return d.Parents },
+	"derived":      func(d *DataInfo) derivedList { return derivedList{} },
+	"mermaid":      func(d *DataInfo) string { return "" },
+}
+
+// pageFuncs returns the template funcs linking to the types of the project, relative to page.
+func pageFuncs(page *Page) template.FuncMap {
+	typeHref := func(name string) string {
+		if symbol, ok := page.Project.Symbols.Lookup(name); ok {
+			return page.Project.Symbols.Href(page.Path, symbol)
+		}
+		return ""
+	}
+	return template.FuncMap{
+		"typeName":   page.typeName,
+		"typeAnchor": page.typeAnchor,
+		"typeHref":   typeHref,
+		"typeLink": func(name string) string {
+			if href := typeHref(name); href != "" {
+				return "[`" + name + "`](" + href + ")"
+			}
+			return "`" + name + "`"
+		},
+		"seeLink": func(ref string) string {
+			if symbol, ok := page.Project.Symbols.LookupReference(ref); ok {
+				return "[`" + ref + "`](" + page.Project.Symbols.Href(page.Path, symbol) + ")"
+			}
+			return "`" + ref + "`"
+		},
+		"parents": page.Project.Parents,
+		"derived": page.Project.Derived,
+		"mermaid": func(d *DataInfo) string { return page.Project.TypeMermaid(d, page.Path) },
+		"typeMentions": func(declarations any, owner string) []Symbol {
+			switch declarations := declarations.(type) {
+			case string:
+				return page.typeMentions([]string{declarations}, owner)
+			case []string:
+				return page.typeMentions(declarations, owner)
+			}
+			return nil
+		},
+	}
+}
+
+// templateRenderer renders pages with text/template. It starts from the built-in templates, which write the MDX
+// format, and replaces those found in a user folder.
+type templateRenderer struct {
+	templates *template.Template
+	extension string
+}
+
+// newTemplateRenderer loads the built-in templates, then every *.tmpl of folder. A template is named after its file
+// name without extension, so folder/function.tmpl replaces the built-in "function" template.
+func newTemplateRenderer(folder string) (*templateRenderer, error) {
+	root := template.New("templates").Funcs(templateFuncs)
+
+	builtins, err := fs.Glob(builtinTemplates, builtinTemplateFolder+"/*.tmpl")
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range builtins {
+		content, err := builtinTemplates.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if err := addTemplate(root, name, content); err != nil {
+			return nil, err
+		}
+	}
+
+	if folder != "" {
+		userTemplates, err := filepath.Glob(filepath.Join(folder, "*.tmpl"))
+		if err != nil {
+			return nil, err
+		}
+		if len(userTemplates) == 0 {
+			return nil, fmt.Errorf("no *.tmpl file found in %s", folder)
+		}
+		for _, name := range userTemplates {
+			content, err := os.ReadFile(name)
+			if err != nil {
+				return nil, err
+			}
+			if err := addTemplate(root, name, content); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return &templateRenderer{templates: root, extension: ".mdx"}, nil
+}
+
+// addTemplate parses a template file. Its final newline is dropped, so files can end with one without it showing in pages.
+func addTemplate(root *template.Template, fileName string, content []byte) error {
+	name := strings.TrimSuffix(filepath.Base(fileName), filepath.Ext(fileName))
+	text := strings.TrimSuffix(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+
+	if _, err := root.New(name).Parse(text); err != nil {
+		return fmt.Errorf("parsing template %s: %w", fileName, err)
+	}
+	return nil
+}
+
+func (t *templateRenderer) Extension() string {
+	return t.extension
+}
+
+func (t *templateRenderer) RenderPage(writer *bufio.Writer, page *Page) error {
+	data := templatePage{
+		File:        page.File,
+		FrontMatter: page.FrontMatter,
+		Project:     page.Project,
+	}
+	data.Enums, data.Structs, data.Classes = page.File.SplitTypes()
+
+	templates, err := t.templates.Clone()
+	if err != nil {
+		return err
+	}
+	var buffer bytes.Buffer
+	if err := templates.Funcs(pageFuncs(page)).ExecuteTemplate(&buffer, "page", data); err != nil {
+		return err
+	}
+
+	content, err := mergeExisting(page.ExistingPath, buffer.String())
+	if err != nil {
+		return err
+	}
+	writer.WriteString(content)
+	return nil
+}
+
+func (t *templateRenderer) RenderIndex(writer *bufio.Writer, index *IndexPage) error {
+	return t.templates.ExecuteTemplate(writer, "index", index)
+}
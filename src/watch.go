@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// fileState is what polling compares to notice a header changed.
+type fileState struct {
+	ModTime time.Time
+	Size    int64
+}
+
+// snapshot returns the state of every header of the source folder selected by the config, by relative path.
+func snapshot(sourceFolder string, cfg *Config) (map[string]fileState, error) {
+	states := map[string]fileState{}
+	err := filepath.WalkDir(sourceFolder, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		relPath, err := filepath.Rel(sourceFolder, path)
+		if err != nil {
+			return err
+		}
+		if !cfg.ShouldProcess(relPath) {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		states[filepath.ToSlash(relPath)] = fileState{ModTime: info.ModTime(), Size: info.Size()}
+		return nil
+	})
+	return states, err
+}
+
+// changedFiles returns the headers added, modified or removed between two snapshots, sorted, and the removed ones.
+func changedFiles(before, after map[string]fileState) (changed []string, removed []string) {
+	for relPath, state := range after {
+		if previous, ok := before[relPath]; !ok || previous != state {
+			changed = append(changed, relPath)
+		}
+	}
+	for relPath := range before {
+		if _, ok := after[relPath]; !ok {
+			changed = append(changed, relPath)
+			removed = append(removed, relPath)
+		}
+	}
+	slices.Sort(changed)
+	slices.Sort(removed)
+	return changed, removed
+}
+
+// runWatch generates the pages, then polls the source folder and generates them again once a change settled:
+// when the headers stayed the same for a whole interval. The parse cache limits parsing to the changed headers,
+// and only pages whose content changed are written, which includes the pages linking to a renamed type.
+func runWatch(opts *options) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	generated, err := snapshot(opts.SourceFolder, &opts.Config)
+	if err != nil {
+		return err
+	}
+	if err := runGenerate(opts); err != nil {
+		logError("Error: %v\n", err)
+	}
+	logInfo("Watching %s every %s, press Ctrl+C to stop\n", opts.SourceFolder, opts.Interval)
+
+	previous := generated
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		current, err := snapshot(opts.SourceFolder, &opts.Config)
+		if err != nil {
+			logError("Error: %v\n", err)
+			continue
+		}
+		// Wait for editors and checkouts to finish writing before generating
+		if changed, _ := changedFiles(previous, current); len(changed) > 0 {
+			previous = current
+			continue
+		}
+
+		changed, removed := changedFiles(generated, current)
+		if len(changed) == 0 {
+			continue
+		}
+		logInfo("Changed: %s\n", strings.Join(changed, ", "))
+
+		for _, relPath := range removed {
+			removePage(opts, relPath)
+		}
+		if err := runGenerate(opts); err != nil {
+			logError("Error: %v\n", err)
+		}
+		generated = current
+	}
+}
+
+// removePage deletes the page of a header removed from the source folder.
+func removePage(opts *options, relPath string) {
+	fileInfo := &FileInfo{Path: filepath.Join(opts.SourceFolder, filepath.FromSlash(relPath)), RelPath: relPath, Name: filepath.Base(relPath)}
+	pagePath := outputPath(fileInfo, opts.DestFolder, &opts.Config)
+	if opts.Format == "json" {
+		if opts.Combined {
+			return
+		}
+		pagePath = filepath.Join(opts.DestFolder, filepath.FromSlash(jsonFileName(fileInfo, &opts.Config)))
+	}
+
+	if opts.DryRun {
+		logInfo("Would remove %s\n", pagePath)
+		return
+	}
+	if err := os.Remove(pagePath); err != nil && !os.IsNotExist(err) {
+		logError("Error: removing %s: %v\n", pagePath, err)
+		return
+	}
+	logInfo("Removed %s\n", pagePath)
+}
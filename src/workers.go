@@ -0,0 +1,41 @@
+package main
+
+import (
+	"runtime"
+	"sync"
+)
+
+// defaultJobs is the number of workers when -j is not set: one per CPU.
+func defaultJobs() int {
+	return runtime.NumCPU()
+}
+
+// forEachParallel calls work(i) for every i below count on up to jobs goroutines and waits for all of them.
+// work must only write to what belongs to i, e.g. the i-th element of a results slice, so the caller can
+// read the results in order once forEachParallel returns.
+func forEachParallel(count, jobs int, work func(i int)) {
+	jobs = max(1, min(jobs, count))
+	if jobs == 1 {
+		for i := 0; i < count; i++ {
+			work(i)
+		}
+		return
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range jobs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				work(i)
+			}
+		}()
+	}
+	for i := 0; i < count; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"sync/atomic"
+	"testing"
+)
+
+func TestForEachParallel(t *testing.T) {
+	for _, test := range []struct {
+		count, jobs int
+	}{
+		{0, 4},
+		{1, 4},
+		{10, 1},
+		{10, 3},
+		{50, 8},
+		{5, 100},
+	} {
+		calls := make([]int32, test.count)
+		results := make([]int, test.count)
+		var active, peak int32
+		forEachParallel(test.count, test.jobs, func(i int) {
+			running := atomic.AddInt32(&active, 1)
+			for {
+				highest := atomic.LoadInt32(&peak)
+				if running <= highest || atomic.CompareAndSwapInt32(&peak, highest, running) {
+					break
+				}
+			}
+			atomic.AddInt32(&calls[i], 1)
+			results[i] = i * i
+			atomic.AddInt32(&active, -1)
+		})
+
+		for i := range results {
+			if calls[i] != 1 {
+				t.Errorf("count %d, jobs %d: work(%d) called %d times, want once", test.count, test.jobs, i, calls[i])
+			}
+			if results[i] != i*i {
+				t.Errorf("count %d, jobs %d: results[%d] = %d, want %d", test.count, test.jobs, i, results[i], i*i)
+			}
+		}
+		if int(peak) > test.jobs {
+			t.Errorf("count %d, jobs %d: %d calls ran at the same time", test.count, test.jobs, peak)
+		}
+	}
+}